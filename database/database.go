@@ -9,6 +9,7 @@ package database
 
 import (
 	goerrors "errors"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -61,6 +62,12 @@ type PokemonBattleInfo interface {
 	GetPokemonBattleInfo() *pkmn.PokemonBattleInfo
 }
 
+// LedgerEntry describes a database representation of a ledger entry that can
+// emit a pkmn.LedgerEntry.
+type LedgerEntry interface {
+	GetLedgerEntry() *pkmn.LedgerEntry
+}
+
 // Database describes an object that is able to save and load Pokemon
 // constructs.
 type Database interface {
@@ -97,14 +104,34 @@ type Database interface {
 	// LoadPokemon loads a Pokemon with the given UUID. The second return value
 	// is true if the Pokemon exists, false otherwise.
 	LoadPokemon(ctx context.Context, uuid string) (Pokemon, error)
-	// DeletePokemon deletes a Pokemon with the given UUID.
-	DeletePokemon(ctx context.Context, uuid string) error
-	// SaveParty saves a batch of Pokemon as owend by the given trainer.
+	// DeletePokemon deletes the Pokemon with the given UUID from the given
+	// trainer's party.
+	DeletePokemon(ctx context.Context, t Trainer, uuid string) error
+	// SaveParty saves a batch of Pokemon as owend by the given trainer. Each
+	// Pokemon's slot is set to its position in the given party.
 	SaveParty(ctx context.Context, t Trainer, party []Pokemon) error
-	// LoadParty returns all the Pokemon in the given trainer's party. The
-	// second return value is true if any Pokemon were found, false otherwise.
+	// LoadParty returns all the Pokemon in the given trainer's party in slot
+	// order. The second return value is true if any Pokemon were found, false
+	// otherwise.
 	LoadParty(ctx context.Context, t Trainer) ([]Pokemon, error)
 
+	// SaveBoxedPokemon saves the given Pokemon in the given box of the
+	// trainer's PC at the given time. Boxes are numbered from 0 to
+	// pkmn.BoxCount-1. The Pokemon is placed at the end of the box.
+	SaveBoxedPokemon(ctx context.Context, t Trainer, box int, pkmn Pokemon, deposited time.Time) error
+	// LoadBox returns all the Pokemon in the given box of the trainer's PC in
+	// the order they were put there. An empty box is not an error.
+	LoadBox(ctx context.Context, t Trainer, box int) ([]Pokemon, error)
+	// LoadBoxSizes returns the number of Pokemon in each of the boxes of the
+	// trainer's PC.
+	LoadBoxSizes(ctx context.Context, t Trainer) ([]int, error)
+	// MoveBoxedPokemon moves the Pokemon with the given UUID from wherever it
+	// is in the trainer's PC to the end of the given box at the given time.
+	MoveBoxedPokemon(ctx context.Context, t Trainer, uuid string, box int, moved time.Time) error
+	// DeleteBoxedPokemon takes the Pokemon with the given UUID out of the
+	// trainer's PC.
+	DeleteBoxedPokemon(ctx context.Context, t Trainer, uuid string) error
+
 	// NewBattle creates a database battle that is ready to be saved from the
 	// given pkmn.Battle.
 	NewBattle(b pkmn.Battle) Battle
@@ -151,6 +178,13 @@ type Database interface {
 	// given battle.
 	DeletePokemonBattleInfos(ctx context.Context, b Battle) error
 
+	// NewLedgerEntry creates a database ledger entry that is ready to be saved
+	// from the given pkmn.LedgerEntry.
+	NewLedgerEntry(e pkmn.LedgerEntry) LedgerEntry
+	// SaveLedgerEntry adds the given entry to the trainer's ledger. Entries
+	// are never changed once they're saved.
+	SaveLedgerEntry(ctx context.Context, t Trainer, e LedgerEntry) error
+
 	// Transaction runs the given function in a transaction, meaning that the
 	// modified fields are locked down and can't be changed by other
 	// goroutines. It may also be able to roll back changes if an error occurs.
@@ -0,0 +1,191 @@
+package gaedatabase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/pkmn"
+	"golang.org/x/net/context"
+	"google.golang.org/appengine/datastore"
+)
+
+// GAEBoxedPokemon is the database object wrapper of a Pokemon stored in a PC
+// box for datastore.
+type GAEBoxedPokemon struct {
+	pkmn.Pokemon
+	// Box is the box the Pokemon is in.
+	Box int
+	// Deposited is when the Pokemon was put in its box. Pokemon in a box are
+	// ordered by this value.
+	Deposited time.Time
+}
+
+// GetPokemon returns the underlying Pokemon from the database object.
+func (pkmn *GAEBoxedPokemon) GetPokemon() *pkmn.Pokemon {
+	return &pkmn.Pokemon
+}
+
+// byDeposited sorts boxed Pokemon by when they were deposited.
+type byDeposited []*GAEBoxedPokemon
+
+func (b byDeposited) Len() int           { return len(b) }
+func (b byDeposited) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byDeposited) Less(i, j int) bool { return b[i].Deposited.Before(b[j].Deposited) }
+
+// checkBox returns an error if the given box number is out of range.
+func checkBox(box int) error {
+	if box < 0 || box >= pkmn.BoxCount {
+		return errors.Errorf("box %v does not exist", box)
+	}
+	return nil
+}
+
+// SaveBoxedPokemon saves the given Pokemon in the given box of the trainer's
+// PC at the given time.
+func (db GAEDatabase) SaveBoxedPokemon(ctx context.Context, dbt database.Trainer, box int, dbpkmn database.Pokemon, deposited time.Time) error {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+	p, ok := dbpkmn.(*GAEPokemon)
+	if !ok {
+		panic("The given Pokemon is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+	err := checkBox(box)
+	if err != nil {
+		return errors.Wrap(err, "saving boxed Pokemon")
+	}
+
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+	pkmnKey := datastore.NewKey(ctx, boxedPokemonKindName, p.UUID, 0, trainerKey)
+
+	boxed := GAEBoxedPokemon{
+		Pokemon:   p.Pokemon,
+		Box:       box,
+		Deposited: deposited}
+	_, err = datastore.Put(ctx, pkmnKey, &boxed)
+	if err != nil {
+		return errors.Wrap(err, "saving boxed Pokemon")
+	}
+
+	return nil
+}
+
+// LoadBox returns all the Pokemon in the given box of the trainer's PC in the
+// order they were put there.
+func (db GAEDatabase) LoadBox(ctx context.Context, dbt database.Trainer, box int) ([]database.Pokemon, error) {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+	err := checkBox(box)
+	if err != nil {
+		return make([]database.Pokemon, 0), errors.Wrap(err, "loading box")
+	}
+
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+
+	var gaeBox []*GAEBoxedPokemon
+	_, err = datastore.NewQuery(boxedPokemonKindName).
+		Ancestor(trainerKey).
+		Filter("Box =", box).
+		GetAll(ctx, &gaeBox)
+	if err != nil {
+		return make([]database.Pokemon, 0), errors.Wrap(err, "querying for boxed Pokemon")
+	}
+
+	// Sorting is done here to avoid needing a composite index
+	sort.Sort(byDeposited(gaeBox))
+
+	// Create the interface representation of the box
+	pkmns := make([]database.Pokemon, len(gaeBox))
+	for i, val := range gaeBox {
+		val.MigrateExperience()
+		pkmns[i] = val
+	}
+
+	return pkmns, nil
+}
+
+// LoadBoxSizes returns the number of Pokemon in each of the boxes of the
+// trainer's PC.
+func (db GAEDatabase) LoadBoxSizes(ctx context.Context, dbt database.Trainer) ([]int, error) {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+
+	var gaeBoxes []*GAEBoxedPokemon
+	_, err := datastore.NewQuery(boxedPokemonKindName).
+		Ancestor(trainerKey).
+		GetAll(ctx, &gaeBoxes)
+	if err != nil {
+		return make([]int, pkmn.BoxCount), errors.Wrap(err, "querying for boxed Pokemon")
+	}
+
+	sizes := make([]int, pkmn.BoxCount)
+	for _, val := range gaeBoxes {
+		if checkBox(val.Box) == nil {
+			sizes[val.Box]++
+		}
+	}
+
+	return sizes, nil
+}
+
+// MoveBoxedPokemon moves the Pokemon with the given UUID from wherever it is
+// in the trainer's PC to the end of the given box at the given time.
+func (db GAEDatabase) MoveBoxedPokemon(ctx context.Context, dbt database.Trainer, uuid string, box int, moved time.Time) error {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+	err := checkBox(box)
+	if err != nil {
+		return errors.Wrap(err, "moving boxed Pokemon")
+	}
+
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+	pkmnKey := datastore.NewKey(ctx, boxedPokemonKindName, uuid, 0, trainerKey)
+
+	var boxed GAEBoxedPokemon
+	err = datastore.Get(ctx, pkmnKey, &boxed)
+	if err == datastore.ErrNoSuchEntity {
+		return errors.Wrap(database.ErrNoResults, "moving boxed Pokemon")
+	} else if err != nil {
+		return errors.Wrap(err, "moving boxed Pokemon")
+	}
+
+	boxed.Box = box
+	boxed.Deposited = moved
+	_, err = datastore.Put(ctx, pkmnKey, &boxed)
+	if err != nil {
+		return errors.Wrap(err, "moving boxed Pokemon")
+	}
+
+	return nil
+}
+
+// DeleteBoxedPokemon takes the Pokemon with the given UUID out of the
+// trainer's PC.
+func (db GAEDatabase) DeleteBoxedPokemon(ctx context.Context, dbt database.Trainer, uuid string) error {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+	pkmnKey := datastore.NewKey(ctx, boxedPokemonKindName, uuid, 0, trainerKey)
+
+	err := datastore.Delete(ctx, pkmnKey)
+	if err != nil {
+		return errors.Wrap(err, "deleting boxed Pokemon")
+	}
+
+	return nil
+}
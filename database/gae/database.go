@@ -15,11 +15,13 @@ import (
 
 const (
 	pokemonKindName           = "Pokemon"
+	boxedPokemonKindName      = "BoxedPokemon"
 	trainerKindName           = "Trainer"
 	battleKindName            = "Battle"
 	trainerBattleInfoKindName = "TrainerBattleInfo"
 	pokemonBattleInfoKindName = "PokemonBattleInfo"
 	lastContactURLKindName    = "LastContactURL"
+	ledgerEntryKindName       = "LedgerEntry"
 )
 
 // GAEDatabase is the datastore implementation of the database interface.
@@ -0,0 +1,51 @@
+package gaedatabase
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/pkmn"
+	"golang.org/x/net/context"
+	"google.golang.org/appengine/datastore"
+)
+
+// GAELedgerEntry is the database object wrapper of a ledger entry for
+// datastore.
+type GAELedgerEntry struct {
+	pkmn.LedgerEntry
+}
+
+// NewLedgerEntry creates a database ledger entry that is ready to be saved
+// from the given pkmn.LedgerEntry.
+func (db GAEDatabase) NewLedgerEntry(e pkmn.LedgerEntry) database.LedgerEntry {
+	return &GAELedgerEntry{LedgerEntry: e}
+}
+
+// GetLedgerEntry returns the underlying ledger entry from the database object.
+func (e *GAELedgerEntry) GetLedgerEntry() *pkmn.LedgerEntry {
+	return &e.LedgerEntry
+}
+
+// SaveLedgerEntry adds the given entry to the trainer's ledger. Entries are
+// stored under the trainer so they're saved in the same transaction as the
+// balance they record.
+func (db GAEDatabase) SaveLedgerEntry(ctx context.Context, dbt database.Trainer, dbe database.LedgerEntry) error {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+	e, ok := dbe.(*GAELedgerEntry)
+	if !ok {
+		panic("The given ledger entry is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
+
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+	entryKey := datastore.NewIncompleteKey(ctx, ledgerEntryKindName, trainerKey)
+
+	_, err := datastore.Put(ctx, entryKey, e)
+	if err != nil {
+		return errors.Wrap(err, "saving ledger entry")
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package gaedatabase
 
 import (
+	"sort"
+
 	"github.com/pkg/errors"
 
 	"github.com/velovix/snoreslacks/database"
@@ -14,6 +16,13 @@ type GAEPokemon struct {
 	pkmn.Pokemon
 }
 
+// bySlot sorts Pokemon by their position in the party.
+type bySlot []*GAEPokemon
+
+func (b bySlot) Len() int           { return len(b) }
+func (b bySlot) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b bySlot) Less(i, j int) bool { return b[i].Slot < b[j].Slot }
+
 // NewPokemon creates a database Pokemon that is ready to be saved from the
 // given pkmn.Pokemon.
 func (db GAEDatabase) NewPokemon(p pkmn.Pokemon) database.Pokemon {
@@ -63,42 +72,44 @@ func (db GAEDatabase) LoadPokemon(ctx context.Context, uuid string) (database.Po
 		return &GAEPokemon{}, errors.Wrap(database.ErrNoResults, "loading Pokemon")
 	}
 
+	pkmns[0].MigrateExperience()
+
 	return pkmns[0], nil
 }
 
-// DeletePokemon deletes a Pokemon with the given UUID.
-func (db GAEDatabase) DeletePokemon(ctx context.Context, uuid string) error {
-	var pkmns []*GAEPokemon
+// DeletePokemon deletes the Pokemon with the given UUID from the given
+// trainer's party.
+func (db GAEDatabase) DeletePokemon(ctx context.Context, dbt database.Trainer, uuid string) error {
+	t, ok := dbt.(*GAETrainer)
+	if !ok {
+		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
+	}
 
-	keys, err := datastore.NewQuery(pokemonKindName).
-		Filter("UUID =", uuid).
-		GetAll(ctx, &pkmns)
+	trainerKey := datastore.NewKey(ctx, trainerKindName, t.UUID, 0, nil)
+	pkmnKey := datastore.NewKey(ctx, pokemonKindName, uuid, 0, trainerKey)
+
+	err := datastore.Delete(ctx, pkmnKey)
 	if err != nil {
 		return errors.Wrap(err, "deleting Pokemon")
 	}
 
-	if len(pkmns) == 0 {
-		return errors.New("no Pokemon with the UUID " + uuid + " found to delete")
-	}
-	if len(pkmns) > 1 {
-		return errors.New("multiple Pokemon with the UUID " + uuid + " found while looking for a Pokemon to delete")
-	}
-
-	return datastore.Delete(ctx, keys[0])
+	return nil
 }
 
-// SaveParty saves a batch of Pokemon as owend by the given trainer.
+// SaveParty saves a batch of Pokemon as owend by the given trainer. Each
+// Pokemon's slot is set to its position in the given party.
 func (db GAEDatabase) SaveParty(ctx context.Context, dbt database.Trainer, party []database.Pokemon) error {
 	t, ok := dbt.(*GAETrainer)
 	if !ok {
 		panic("The given trainer is not of the right type for this implementation. Are you using two implementations by mistake?")
 	}
 
-	for _, dbpkmn := range party {
+	for i, dbpkmn := range party {
 		pkmn, ok := dbpkmn.(*GAEPokemon)
 		if !ok {
 			panic("One of the given Pokemon is not of the right type for this implementation. Are you using two implementations by mistake?")
 		}
+		pkmn.Slot = i
 
 		err := db.SavePokemon(ctx, t, pkmn)
 		if err != nil {
@@ -108,8 +119,9 @@ func (db GAEDatabase) SaveParty(ctx context.Context, dbt database.Trainer, party
 	return nil
 }
 
-// LoadParty returns all the Pokemon in the given trainer's party. The
-// second return value is true if any Pokemon were found, false otherwise.
+// LoadParty returns all the Pokemon in the given trainer's party in slot
+// order. The second return value is true if any Pokemon were found, false
+// otherwise.
 func (db GAEDatabase) LoadParty(ctx context.Context, dbt database.Trainer) ([]database.Pokemon, error) {
 	t, ok := dbt.(*GAETrainer)
 	if !ok {
@@ -130,9 +142,13 @@ func (db GAEDatabase) LoadParty(ctx context.Context, dbt database.Trainer) ([]da
 		return make([]database.Pokemon, 0), errors.Wrap(database.ErrNoResults, "loading party")
 	}
 
+	// Sorting is done here to avoid needing a composite index
+	sort.Stable(bySlot(gaeParty))
+
 	// Create the interface representation of the party
 	party := make([]database.Pokemon, len(gaeParty))
 	for i, val := range gaeParty {
+		val.MigrateExperience()
 		party[i] = val
 	}
 
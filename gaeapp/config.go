@@ -3,11 +3,15 @@ package gaeapp
 import (
 	"io/ioutil"
 
+	"github.com/velovix/snoreslacks/pkmn"
 	"gopkg.in/yaml.v2"
 )
 
 var config struct {
 	Token string
+	// ShinyOdds is the chance of a wild Pokemon being shiny, as in one in
+	// this many. The default odds are used if it's left out.
+	ShinyOdds int `yaml:"shiny_odds"`
 }
 
 // loadConfig loads the configuration information from the config file.
@@ -21,4 +25,11 @@ func loadConfig() {
 	if err != nil {
 		panic("while loading config file: " + err.Error())
 	}
+
+	if config.ShinyOdds != 0 {
+		err = pkmn.SetShinyOdds(config.ShinyOdds)
+		if err != nil {
+			panic("while loading config file: " + err.Error())
+		}
+	}
 }
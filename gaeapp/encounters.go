@@ -0,0 +1,69 @@
+package gaeapp
+
+import (
+	"io/ioutil"
+
+	"github.com/velovix/snoreslacks/pkmn"
+	"gopkg.in/yaml.v2"
+)
+
+// encounterFileArea is an area as it's written in the encounter tables file.
+type encounterFileArea struct {
+	Name   string
+	Badges int
+	Wilds  []struct {
+		ID       int
+		Weight   int
+		MinLevel int `yaml:"min_level"`
+		MaxLevel int `yaml:"max_level"`
+		Time     string
+	}
+}
+
+// loadEncounterTables loads the areas and wild Pokemon of every region from
+// the encounter tables file and validates them.
+func loadEncounterTables() {
+	data, err := ioutil.ReadFile("./encounters.yaml")
+	if err != nil {
+		panic("while loading encounter tables: " + err.Error())
+	}
+
+	var file map[string][]encounterFileArea
+	err = yaml.UnmarshalStrict(data, &file)
+	if err != nil {
+		panic("while loading encounter tables: " + err.Error())
+	}
+
+	tables := make(map[pkmn.Region][]pkmn.Area)
+	for regionName, fileAreas := range file {
+		region, ok := pkmn.NameToRegion(regionName)
+		if !ok {
+			panic("while loading encounter tables: unknown region " + regionName)
+		}
+
+		for _, fileArea := range fileAreas {
+			area := pkmn.Area{Name: fileArea.Name, Badges: fileArea.Badges}
+			for _, fileWild := range fileArea.Wilds {
+				tod := pkmn.AnyTimeOfDay
+				if fileWild.Time != "" {
+					tod, ok = pkmn.NameToTimeOfDay(fileWild.Time)
+					if !ok {
+						panic("while loading encounter tables: unknown time of day " + fileWild.Time)
+					}
+				}
+				area.Wilds = append(area.Wilds, pkmn.WildEntry{
+					ID:       fileWild.ID,
+					Weight:   fileWild.Weight,
+					MinLevel: fileWild.MinLevel,
+					MaxLevel: fileWild.MaxLevel,
+					Time:     tod})
+			}
+			tables[region] = append(tables[region], area)
+		}
+	}
+
+	err = pkmn.SetEncounterTables(tables)
+	if err != nil {
+		panic("while loading encounter tables: " + err.Error())
+	}
+}
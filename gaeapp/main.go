@@ -8,6 +8,7 @@ import (
 	"github.com/velovix/snoreslacks/handlers"
 	"github.com/velovix/snoreslacks/logging"
 	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
 	"github.com/velovix/snoreslacks/pokeapi"
 	"github.com/velovix/snoreslacks/tasking"
 
@@ -22,6 +23,8 @@ import (
 
 func init() {
 	loadConfig()
+	loadEncounterTables()
+	loadMartCatalogue()
 
 	// Get all Google App Engine dependencies
 	ctxCreator, err := ctxman.Get("gae")
@@ -53,7 +56,9 @@ func init() {
 		Log:           log,
 		ClientCreator: clientCreator,
 		Fetcher:       fetcher,
-		WorkQueue:     queue}
+		WorkQueue:     queue,
+		Clock:         pkmn.SystemClock{},
+		RNG:           pkmn.SystemRNG{}}
 
 	http.Handle(handlers.WaitingHelpURL, handlers.Runner{
 		Servs: services,
@@ -115,6 +120,114 @@ func init() {
 		Servs: services,
 		Task:  &handlers.NoForgetMove{}})
 
+	http.Handle(handlers.ViewBagURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ViewBag{}})
+
+	http.Handle(handlers.UseItemURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.UseItem{}})
+
+	http.Handle(handlers.GiveItemURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.GiveItem{}})
+
+	http.Handle(handlers.TakeItemURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.TakeItem{}})
+
+	http.Handle(handlers.GymBattleURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.GymBattle{}})
+
+	http.Handle(handlers.EvolvingHelpURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.EvolvingHelp{}})
+
+	http.Handle(handlers.EvolveURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Evolve{}})
+
+	http.Handle(handlers.CancelEvolutionURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.CancelEvolution{}})
+
+	http.Handle(handlers.UseItemOOBURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.UseItemOOB{}})
+
+	http.Handle(handlers.WalkURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Walk{}})
+
+	http.Handle(handlers.HealURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Heal{}})
+
+	http.Handle(handlers.ViewDexURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ViewDex{}})
+
+	http.Handle(handlers.TravelURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Travel{}})
+
+	http.Handle(handlers.ViewBoxURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ViewBox{}})
+
+	http.Handle(handlers.DepositURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Deposit{}})
+
+	http.Handle(handlers.WithdrawURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Withdraw{}})
+
+	http.Handle(handlers.SwapPartyURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.SwapParty{}})
+
+	http.Handle(handlers.NicknameURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Nickname{}})
+
+	http.Handle(handlers.ReleaseURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Release{}})
+
+	http.Handle(handlers.ReleasingHelpURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ReleasingHelp{}})
+
+	http.Handle(handlers.ConfirmReleaseURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ConfirmRelease{}})
+
+	http.Handle(handlers.CancelReleaseURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.CancelRelease{}})
+
+	http.Handle(handlers.TradeURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.Trade{}})
+
+	http.Handle(handlers.ConfirmTradeURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ConfirmTrade{}})
+
+	http.Handle(handlers.CancelTradeURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.CancelTrade{}})
+
+	http.Handle(handlers.ViewMartURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.ViewMart{}})
+
+	http.Handle(handlers.BuyItemURL, handlers.Runner{
+		Servs: services,
+		Task:  &handlers.BuyItem{}})
+
 	// Set up the main handler to respond to Slack requests
 	mainHandler := &handlers.Main{
 		Services: services,
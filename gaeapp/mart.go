@@ -0,0 +1,47 @@
+package gaeapp
+
+import (
+	"io/ioutil"
+
+	"github.com/velovix/snoreslacks/pkmn"
+	"gopkg.in/yaml.v2"
+)
+
+// martFileItem is an item as it's written in the mart catalogue file.
+type martFileItem struct {
+	Item   string
+	Price  int
+	Badges int
+}
+
+// loadMartCatalogue loads the items sold at the Poke Mart from the mart
+// catalogue file and validates them.
+func loadMartCatalogue() {
+	data, err := ioutil.ReadFile("./mart.yaml")
+	if err != nil {
+		panic("while loading mart catalogue: " + err.Error())
+	}
+
+	var file []martFileItem
+	err = yaml.UnmarshalStrict(data, &file)
+	if err != nil {
+		panic("while loading mart catalogue: " + err.Error())
+	}
+
+	var catalogue []pkmn.MartItem
+	for _, fileItem := range file {
+		item, ok := pkmn.NameToItem(fileItem.Item)
+		if !ok {
+			panic("while loading mart catalogue: unknown item " + fileItem.Item)
+		}
+		catalogue = append(catalogue, pkmn.MartItem{
+			Item:   item,
+			Price:  fileItem.Price,
+			Badges: fileItem.Badges})
+	}
+
+	err = pkmn.SetMartCatalogue(catalogue)
+	if err != nil {
+		panic("while loading mart catalogue: " + err.Error())
+	}
+}
@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+	"golang.org/x/net/context"
+)
+
+// battleStrategy decides what a bot trainer does on its turn.
+type battleStrategy interface {
+	// pickAction returns the action the bot will take this turn against the
+	// foe. Both trainers are part of the given battle data.
+	pickAction(ctx context.Context, s Services, bd *battleData, bot, foe *battleTrainerData) (pkmn.BattleAction, error)
+}
+
+// strategyFor returns the battle strategy the given bot trainer uses. Bots
+// without a strategy of their own fall back on one that suits their type.
+func strategyFor(t *pkmn.Trainer) (battleStrategy, error) {
+	strategy := t.Strategy
+	if strategy == pkmn.DefaultBattleStrategy {
+		switch t.Type {
+		case pkmn.HumanTrainerType:
+			// Human trainers can't have their actions picked automatically
+			return nil, errors.New("attempt to have a human trainer's action picked automatically")
+		case pkmn.WildTrainerType:
+			// Wild Pokemon don't think too hard about what they're doing
+			strategy = pkmn.RandomBattleStrategy
+		case pkmn.GymLeaderTrainerType:
+			strategy = pkmn.GreedyBattleStrategy
+		default:
+			panic("unimplemented trainer type")
+		}
+	}
+
+	switch strategy {
+	case pkmn.RandomBattleStrategy:
+		return randomStrategy{}, nil
+	case pkmn.GreedyBattleStrategy:
+		return greedyStrategy{}, nil
+	case pkmn.MinimaxBattleStrategy:
+		return minimaxStrategy{}, nil
+	default:
+		panic("unimplemented battle strategy")
+	}
+}
+
+// randomStrategy uses a random move it has PP left for every turn.
+type randomStrategy struct{}
+
+func (randomStrategy) pickAction(ctx context.Context, s Services, bd *battleData, bot, foe *battleTrainerData) (pkmn.BattleAction, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	// Pokemon that are out of PP, or never had any moves, struggle
+	moves, err := loadUsableMoves(ctx, client, s.Fetcher, bot.activePkmn().GetPokemon())
+	if err != nil {
+		return pkmn.BattleAction{}, err
+	}
+
+	return pkmn.BattleAction{
+		Type: pkmn.MoveBattleActionType,
+		Val:  moves[rand.Intn(len(moves))].ID}, nil
+}
+
+// greedyStrategy uses the move that is expected to do the most damage right
+// now. If the active Pokemon is low on HP it heals it when it can, and if the
+// active Pokemon can't do any damage it switches to one that can.
+type greedyStrategy struct{}
+
+// lowHPFraction is the fraction of its max HP a bot's Pokemon has to be at or
+// under for the bot to consider healing it.
+const lowHPFraction = 0.25
+
+func (greedyStrategy) pickAction(ctx context.Context, s Services, bd *battleData, bot, foe *battleTrainerData) (pkmn.BattleAction, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	b := bd.battle.GetBattle()
+	user := bot.activePkmn().GetPokemon()
+	userBI := bot.activePkmnBattleInfo().GetPokemonBattleInfo()
+	target := foe.activePkmn().GetPokemon()
+	targetBI := foe.activePkmnBattleInfo().GetPokemonBattleInfo()
+	targetSide := foe.battleInfo.GetTrainerBattleInfo()
+
+	// Heal the active Pokemon if it's about to go down
+	if float64(userBI.CurrHP) <= float64(pkmn.CalcIBHP(*user, *userBI))*lowHPFraction {
+		if item, ok := bestHealingItem(bot.trainer.GetTrainer()); ok {
+			return pkmn.BattleAction{
+				Type:   pkmn.ItemBattleActionType,
+				Val:    int(item),
+				Target: bot.battleInfo.GetTrainerBattleInfo().CurrPkmnSlot}, nil
+		}
+	}
+
+	moves, err := loadUsableMoves(ctx, client, s.Fetcher, user)
+	if err != nil {
+		return pkmn.BattleAction{}, err
+	}
+	move, damage, err := bestMove(b, user, target, userBI, targetBI, targetSide, moves)
+	if err != nil {
+		return pkmn.BattleAction{}, err
+	}
+
+	if damage == 0 {
+		// The active Pokemon can't hurt the foe, so look for a party member
+		// that can
+		bestSlot, bestDamage := -1, 0.0
+		for slot, p := range bot.pkmn {
+			pBI := bot.pkmnBattleInfo[slot].GetPokemonBattleInfo()
+			if slot == bot.battleInfo.GetTrainerBattleInfo().CurrPkmnSlot || pBI.CurrHP <= 0 {
+				continue
+			}
+			memberMoves, err := loadUsableMoves(ctx, client, s.Fetcher, p.GetPokemon())
+			if err != nil {
+				return pkmn.BattleAction{}, err
+			}
+			_, memberDamage, err := bestMove(b, p.GetPokemon(), target, pBI, targetBI, targetSide, memberMoves)
+			if err != nil {
+				return pkmn.BattleAction{}, err
+			}
+			if memberDamage > bestDamage {
+				bestSlot, bestDamage = slot, memberDamage
+			}
+		}
+		if bestSlot != -1 {
+			return pkmn.BattleAction{
+				Type: pkmn.SwitchBattleActionType,
+				Val:  bestSlot}, nil
+		}
+	}
+
+	return pkmn.BattleAction{
+		Type: pkmn.MoveBattleActionType,
+		Val:  move.ID}, nil
+}
+
+// bestMove returns the move that is expected to do the most damage to the
+// target, along with that damage. If none of the moves do damage, a random
+// one is returned.
+func bestMove(b *pkmn.Battle, user, target *pkmn.Pokemon, userBI, targetBI *pkmn.PokemonBattleInfo, targetSide *pkmn.TrainerBattleInfo, moves []pkmn.Move) (pkmn.Move, float64, error) {
+	if len(moves) == 0 {
+		return pkmn.Move{}, 0, errors.New("attempt to pick a move for a Pokemon that has none")
+	}
+
+	best := moves[rand.Intn(len(moves))]
+	bestDamage := 0.0
+	for _, move := range moves {
+		damage, err := pkmn.ExpectedDamage(b, user, target, userBI, targetBI, targetSide, move)
+		if err != nil {
+			return pkmn.Move{}, 0, err
+		}
+		if damage > bestDamage {
+			best, bestDamage = move, damage
+		}
+	}
+	return best, bestDamage, nil
+}
+
+// bestHealingItem returns the item in the trainer's bag that restores the most
+// HP, or false if the trainer has no such item.
+func bestHealingItem(t *pkmn.Trainer) (pkmn.Item, bool) {
+	best := pkmn.NoItem
+	for _, entry := range t.Bag {
+		if entry.Count > 0 && entry.Item.Healing() > best.Healing() {
+			best = entry.Item
+		}
+	}
+	return best, best != pkmn.NoItem
+}
+
+// minimaxStrategy plays out every action the bot could take against every
+// move the foe could reply with, and picks the action whose worst outcome is
+// the best. Outcomes are judged by expected damage, so the lookahead is only
+// one turn deep.
+type minimaxStrategy struct{}
+
+// koBonus is the score given for making a Pokemon faint, on top of the score
+// for the HP it lost.
+const koBonus = 1.0
+
+// simPokemon is a Pokemon as seen by the minimax strategy's simulation.
+type simPokemon struct {
+	p     *pkmn.Pokemon
+	pBI   pkmn.PokemonBattleInfo
+	moves []pkmn.Move
+}
+
+func (minimaxStrategy) pickAction(ctx context.Context, s Services, bd *battleData, bot, foe *battleTrainerData) (pkmn.BattleAction, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	b := bd.battle.GetBattle()
+	botSide := *bot.battleInfo.GetTrainerBattleInfo()
+	foeSide := *foe.battleInfo.GetTrainerBattleInfo()
+
+	foePkmn := simPokemon{
+		p:   foe.activePkmn().GetPokemon(),
+		pBI: *foe.activePkmnBattleInfo().GetPokemonBattleInfo()}
+	var err error
+	foePkmn.moves, err = loadUsableMoves(ctx, client, s.Fetcher, foePkmn.p)
+	if err != nil {
+		return pkmn.BattleAction{}, err
+	}
+
+	// Load every Pokemon the bot could have out this turn
+	party := make(map[int]simPokemon)
+	for slot, p := range bot.pkmn {
+		pBI := *bot.pkmnBattleInfo[slot].GetPokemonBattleInfo()
+		if pBI.CurrHP <= 0 {
+			continue
+		}
+		moves, err := loadUsableMoves(ctx, client, s.Fetcher, p.GetPokemon())
+		if err != nil {
+			return pkmn.BattleAction{}, err
+		}
+		party[slot] = simPokemon{p: p.GetPokemon(), pBI: pBI, moves: moves}
+	}
+	active, ok := party[botSide.CurrPkmnSlot]
+	if !ok {
+		return pkmn.BattleAction{}, errors.New("attempt to pick an action for a fainted Pokemon")
+	}
+
+	// Build the list of actions to try
+	var actions []pkmn.BattleAction
+	for _, move := range active.moves {
+		actions = append(actions, pkmn.BattleAction{Type: pkmn.MoveBattleActionType, Val: move.ID})
+	}
+	for slot := range party {
+		if slot != botSide.CurrPkmnSlot {
+			actions = append(actions, pkmn.BattleAction{Type: pkmn.SwitchBattleActionType, Val: slot})
+		}
+	}
+	if item, ok := bestHealingItem(bot.trainer.GetTrainer()); ok {
+		actions = append(actions, pkmn.BattleAction{Type: pkmn.ItemBattleActionType, Val: int(item), Target: botSide.CurrPkmnSlot})
+	}
+	if len(actions) == 0 {
+		return pkmn.BattleAction{}, errors.New("attempt to pick an action for a Pokemon with no moves")
+	}
+
+	// Find the action with the best worst case
+	best := actions[0]
+	bestScore := math.Inf(-1)
+	for _, action := range actions {
+		worst := math.Inf(1)
+		for _, reply := range foePkmn.moves {
+			score, err := simulateTurn(b, action, reply, active, foePkmn, party, botSide, foeSide)
+			if err != nil {
+				return pkmn.BattleAction{}, err
+			}
+			worst = math.Min(worst, score)
+		}
+		if len(foePkmn.moves) == 0 {
+			// The foe can't do anything, so the only thing that matters is
+			// what the bot does
+			score, err := simulateTurn(b, action, pkmn.Move{}, active, foePkmn, party, botSide, foeSide)
+			if err != nil {
+				return pkmn.BattleAction{}, err
+			}
+			worst = score
+		}
+		if worst > bestScore {
+			best, bestScore = action, worst
+		}
+	}
+
+	return best, nil
+}
+
+// simulateTurn plays out a turn where the bot takes the given action and the
+// foe replies with the given move, using expected damage instead of random
+// rolls. It returns a score for the bot, which is the fraction of max HP the
+// foe lost minus the fraction the bot lost, plus a bonus for every Pokemon
+// that fainted on the other side. A reply with no ID means the foe does
+// nothing.
+func simulateTurn(b *pkmn.Battle, action pkmn.BattleAction, reply pkmn.Move, active, foe simPokemon, party map[int]simPokemon, botSide, foeSide pkmn.TrainerBattleInfo) (float64, error) {
+	botMaxHP := float64(pkmn.CalcIBHP(*active.p, active.pBI))
+	foeMaxHP := float64(pkmn.CalcIBHP(*foe.p, foe.pBI))
+	botStartHP := float64(active.pBI.CurrHP)
+	foeHP := float64(foe.pBI.CurrHP)
+
+	// attack deals the expected damage of the move and returns the HP the
+	// target has left
+	attack := func(user, target simPokemon, targetHP float64, targetSide *pkmn.TrainerBattleInfo, move pkmn.Move) (float64, error) {
+		damage, err := pkmn.ExpectedDamage(b, user.p, target.p, &user.pBI, &target.pBI, targetSide, move)
+		if err != nil {
+			return 0, err
+		}
+		return math.Max(targetHP-damage, 0), nil
+	}
+
+	var err error
+	switch action.Type {
+	case pkmn.SwitchBattleActionType:
+		// Switches happen before moves, so the new Pokemon takes the hit
+		active = party[action.Val]
+		botMaxHP = float64(pkmn.CalcIBHP(*active.p, active.pBI))
+		botStartHP = float64(active.pBI.CurrHP)
+		botHP := botStartHP
+		if reply.ID != 0 {
+			botHP, err = attack(foe, active, botHP, &botSide, reply)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return scoreTurn(botStartHP, botHP, botMaxHP, float64(foe.pBI.CurrHP), foeHP, foeMaxHP), nil
+	case pkmn.ItemBattleActionType:
+		// Items are used before moves too
+		healing := float64(pkmn.Item(action.Val).Healing())
+		botHP := math.Min(botStartHP+healing, botMaxHP)
+		if reply.ID != 0 {
+			botHP, err = attack(foe, active, botHP, &botSide, reply)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return scoreTurn(botStartHP, botHP, botMaxHP, float64(foe.pBI.CurrHP), foeHP, foeMaxHP), nil
+	case pkmn.MoveBattleActionType:
+		var move pkmn.Move
+		for _, m := range active.moves {
+			if m.ID == action.Val {
+				move = m
+			}
+		}
+
+		botHP := botStartHP
+		if reply.ID == 0 {
+			foeHP, err = attack(active, foe, foeHP, &foeSide, move)
+			if err != nil {
+				return 0, err
+			}
+			return scoreTurn(botStartHP, botHP, botMaxHP, float64(foe.pBI.CurrHP), foeHP, foeMaxHP), nil
+		}
+
+		// The Pokemon that goes second only gets to move if it's still
+		// standing
+		order := pkmn.CalcMoveOrder(*active.p, *foe.p, active.pBI, foe.pBI, botSide, foeSide, move, reply, b.Weather)
+		if order == 1 {
+			foeHP, err = attack(active, foe, foeHP, &foeSide, move)
+			if err == nil && foeHP > 0 {
+				botHP, err = attack(foe, active, botHP, &botSide, reply)
+			}
+		} else {
+			botHP, err = attack(foe, active, botHP, &botSide, reply)
+			if err == nil && botHP > 0 {
+				foeHP, err = attack(active, foe, foeHP, &foeSide, move)
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		return scoreTurn(botStartHP, botHP, botMaxHP, float64(foe.pBI.CurrHP), foeHP, foeMaxHP), nil
+	default:
+		return 0, errors.New("attempt to simulate an unsupported action")
+	}
+}
+
+// scoreTurn scores the outcome of a simulated turn for the bot.
+func scoreTurn(botStartHP, botHP, botMaxHP, foeStartHP, foeHP, foeMaxHP float64) float64 {
+	score := (foeStartHP-foeHP)/foeMaxHP - (botStartHP-botHP)/botMaxHP
+	if foeHP <= 0 {
+		score += koBonus
+	}
+	if botHP <= 0 {
+		score -= koBonus
+	}
+	return score
+}
@@ -26,8 +26,8 @@ func makeTextHPBar(p *pkmn.Pokemon, pBI *pkmn.PokemonBattleInfo) string {
 }
 
 // makeActionOptions makes and sends each player their move and party switching
-// options.
-func makeActionOptions(ctx context.Context, s Services, trainerData *basicTrainerData, trainerDataBI database.TrainerBattleInfo) error {
+// options. The battle is used to show the current weather.
+func makeActionOptions(ctx context.Context, s Services, b *pkmn.Battle, trainerData *basicTrainerData, trainerDataBI database.TrainerBattleInfo) error {
 	// Load request-specific objects
 	client := ctx.Value("client").(messaging.Client)
 
@@ -35,29 +35,41 @@ func makeActionOptions(ctx context.Context, s Services, trainerData *basicTraine
 	currPkmn := trainerData.pkmn[trainerDataBI.GetTrainerBattleInfo().CurrPkmnSlot]
 
 	// Create the move selector
-	var moveSlots []string
+	type moveSlot struct {
+		Name  string
+		PP    int
+		MaxPP int
+	}
+	var moveSlots []moveSlot
 	for _, moveID := range currPkmn.GetPokemon().MoveIDsAsSlice() {
-		// Load the move info to get the name
+		// Load the move info to get the name and PP
 		move, err := loadMove(ctx, client, s.Fetcher, moveID)
 		if err != nil {
 			return errors.Wrap(err, "making action options")
 		}
-		moveSlots = append(moveSlots, move.Name)
+		moveSlots = append(moveSlots, moveSlot{
+			Name:  move.Name,
+			PP:    currPkmn.GetPokemon().PPLeft(move),
+			MaxPP: move.PP})
 	}
 
 	// Create the party selector
 	var partySlots []string
 	for _, pkmn := range trainerData.pkmn {
-		partySlots = append(partySlots, pkmn.GetPokemon().Name)
+		partySlots = append(partySlots, pkmn.GetPokemon().DisplayName())
 	}
 
 	// Send action options to the player
 	templInfo := struct {
 		CurrPokemonName string
-		MoveSlots       []string
+		Weather         string
+		WeatherTurns    int
+		MoveSlots       []moveSlot
 		PartySlots      []string
 	}{
-		CurrPokemonName: currPkmn.GetPokemon().Name,
+		CurrPokemonName: currPkmn.GetPokemon().DisplayName(),
+		Weather:         b.Weather.Name(),
+		WeatherTurns:    b.WeatherTurns,
 		MoveSlots:       moveSlots,
 		PartySlots:      partySlots}
 	err := messaging.SendTempl(client, trainerData.lastContactURL, messaging.TemplMessage{
@@ -69,3 +81,173 @@ func makeActionOptions(ctx context.Context, s Services, trainerData *basicTraine
 
 	return nil
 }
+
+// pokemonActionPrefix returns the text that should preface the name of a
+// Pokemon owned by the given trainer when describing what happened to it, like
+// "ash.ketchum's" or "The wild".
+func pokemonActionPrefix(t *pkmn.Trainer) string {
+	if t.Type == pkmn.WildTrainerType {
+		return "The wild"
+	}
+	return t.Name + "'s"
+}
+
+// sendHeldItemReport sends a message describing the effects of a Pokemon's
+// held item if it activated.
+func sendHeldItemReport(client messaging.Client, url string, public bool, ir pkmn.ItemReport, holderTrainer *pkmn.Trainer, holder *pkmn.Pokemon) error {
+	if !ir.Activated {
+		// Nothing happened, so there's nothing to report
+		return nil
+	}
+
+	templInfo := struct {
+		pkmn.ItemReport
+		HolderActionPrefix string
+		HolderPokemonName  string
+		ItemName           string
+	}{
+		ItemReport:         ir,
+		HolderActionPrefix: pokemonActionPrefix(holderTrainer),
+		HolderPokemonName:  holder.DisplayName(),
+		ItemName:           ir.Item.Name()}
+	return messaging.SendTempl(client, url, messaging.TemplMessage{
+		Templ:     heldItemReportTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+}
+
+// sendAbilityReport sends a message describing the effects of an ability if it
+// activated. The holder is the Pokemon with the ability and the opponent is
+// the Pokemon it is battling.
+func sendAbilityReport(client messaging.Client, url string, public bool, ar pkmn.AbilityReport, holderTrainer *pkmn.Trainer, holder *pkmn.Pokemon, opponentTrainer *pkmn.Trainer, opponent *pkmn.Pokemon) error {
+	if !ar.Activated {
+		// Nothing happened, so there's nothing to report
+		return nil
+	}
+
+	templInfo := struct {
+		pkmn.AbilityReport
+		HolderActionPrefix   string
+		HolderPokemonName    string
+		OpponentActionPrefix string
+		OpponentPokemonName  string
+	}{
+		AbilityReport:        ar,
+		HolderActionPrefix:   pokemonActionPrefix(holderTrainer),
+		HolderPokemonName:    holder.DisplayName(),
+		OpponentActionPrefix: pokemonActionPrefix(opponentTrainer),
+		OpponentPokemonName:  opponent.DisplayName()}
+	err := messaging.SendTempl(client, url, messaging.TemplMessage{
+		Templ:     abilityReportTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+	if err != nil {
+		return err
+	}
+
+	// Announce any weather the ability started
+	if ar.WeatherStarted != pkmn.NoWeather {
+		return sendWeatherReport(client, url, public, ar.WeatherStarted, true, false)
+	}
+
+	return nil
+}
+
+// sendWeatherReport sends a message describing the state of the given weather.
+// Started is true if the weather just started and ended is true if it just
+// cleared up. If neither is true, the weather is continuing.
+func sendWeatherReport(client messaging.Client, url string, public bool, weather pkmn.Weather, started, ended bool) error {
+	templInfo := struct {
+		Weather string
+		Started bool
+		Ended   bool
+	}{
+		Weather: weather.Name(),
+		Started: started,
+		Ended:   ended}
+	return messaging.SendTempl(client, url, messaging.TemplMessage{
+		Templ:     weatherReportTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+}
+
+// sideOwnerPrefix returns the text that should preface a mention of the given
+// trainer's side of the field, like "ash.ketchum's" or "the wild pidgey's".
+func sideOwnerPrefix(t *pkmn.Trainer) string {
+	if t.Type == pkmn.WildTrainerType {
+		return "the wild " + t.Name + "'s"
+	}
+	return t.Name + "'s"
+}
+
+// sendSideConditionReport sends a message describing the given side condition
+// on the owner's side of the field. Started is true if the condition was just
+// created, and false if it just ran out.
+func sendSideConditionReport(client messaging.Client, url string, public bool, sc pkmn.SideCondition, owner *pkmn.Trainer, started bool) error {
+	templInfo := struct {
+		Condition string
+		SideOwner string
+		Started   bool
+	}{
+		Condition: sc.Name(),
+		SideOwner: sideOwnerPrefix(owner),
+		Started:   started}
+	return messaging.SendTempl(client, url, messaging.TemplMessage{
+		Templ:     sideConditionReportTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+}
+
+// sendIfMoveForced tells the trainer that their active Pokemon is busy with a
+// move it has to keep using and returns true. If the trainer is free to pick
+// their action, false is returned and nothing is sent.
+func sendIfMoveForced(ctx context.Context, s Services, t *battleTrainerData) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	moveID, forced := t.activePkmnBattleInfo().GetPokemonBattleInfo().ForcedMove()
+	if !forced {
+		return false, nil
+	}
+
+	move, err := loadMove(ctx, client, s.Fetcher, moveID)
+	if err != nil {
+		return false, errors.Wrap(err, "sending forced move message")
+	}
+
+	templInfo := struct {
+		PokemonName string
+		MoveName    string
+	}{
+		PokemonName: t.activePkmn().GetPokemon().DisplayName(),
+		MoveName:    move.Name}
+	err = messaging.SendTempl(client, t.lastContactURL, messaging.TemplMessage{
+		Templ:     moveForcedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return false, errors.Wrap(err, "sending forced move message")
+	}
+
+	return true, nil
+}
+
+// sendIfMustReplace tells the trainer that they have to replace their fainted
+// Pokemon before doing anything else and returns true. If the trainer doesn't
+// have to, false is returned and nothing is sent.
+func sendIfMustReplace(ctx context.Context, t *battleTrainerData) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	if !t.battleInfo.GetTrainerBattleInfo().MustReplace {
+		return false, nil
+	}
+
+	err := messaging.SendTempl(client, t.lastContactURL, messaging.TemplMessage{
+		Templ:     mustReplaceTemplate,
+		TemplInfo: t.activePkmn().GetPokemon().DisplayName()})
+	if err != nil {
+		return false, errors.Wrap(err, "sending must replace message")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// boxPageSize is the number of Pokemon shown on a single page of a box
+// listing.
+const boxPageSize = 10
+
+// errPCFull is returned when a Pokemon can't be put in a trainer's PC because
+// every box is full.
+var errPCFull = errors.New("every box in the PC is full")
+
+// boxPokemon puts the given Pokemon in the first box in the trainer's PC that
+// has room for it at the given time and returns the index of that box.
+// errPCFull is returned if there is no room left.
+func boxPokemon(ctx context.Context, db database.Database, t database.Trainer, p database.Pokemon, when time.Time) (int, error) {
+	sizes, err := db.LoadBoxSizes(ctx, t)
+	if err != nil {
+		return 0, err
+	}
+	box, ok := pkmn.FirstBoxWithSpace(sizes)
+	if !ok {
+		return 0, errPCFull
+	}
+
+	err = db.SaveBoxedPokemon(ctx, t, box, p, when)
+	if err != nil {
+		return 0, err
+	}
+
+	return box, nil
+}
+
+// parseBoxID extracts a box ID from the given command parameter. If the
+// parameter isn't a valid box ID, the trainer is told so and false is
+// returned. Box IDs start at 1.
+func parseBoxID(client messaging.Client, url string, param string) (int, bool, error) {
+	boxID, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, false, sendInvalidCommand(client, url)
+	}
+	if boxID < 1 || boxID > pkmn.BoxCount {
+		err = messaging.SendTempl(client, url, messaging.TemplMessage{
+			Templ:     invalidBoxTemplate,
+			TemplInfo: boxID})
+		if err != nil {
+			return 0, false, handlerError{user: "could not populate invalid box template", err: err}
+		}
+		return 0, false, nil
+	}
+
+	return boxID, true, nil
+}
+
+// ViewBox manages requests to see what's in the trainer's PC. With no
+// parameters, the number of Pokemon in each box is shown. Otherwise, a page of
+// the given box is listed.
+type ViewBox struct {
+	Services
+}
+
+func (h *ViewBox) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) > 2 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	if len(slackReq.CommandParams) == 0 {
+		// Show an overview of all the boxes
+		sizes, err := s.DB.LoadBoxSizes(ctx, requester.trainer)
+		if err != nil {
+			return handlerError{user: "could not load the PC", err: err}
+		}
+		templInfo := struct {
+			Sizes        []int
+			BoxSize      int
+			SlashCommand string
+		}{
+			Sizes:        sizes,
+			BoxSize:      pkmn.BoxSize,
+			SlashCommand: slackReq.SlashCommand}
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     viewBoxesTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate view boxes template", err: err}
+		}
+		return nil
+	}
+
+	// Find the box to list
+	boxID, ok, err := parseBoxID(client, requester.lastContactURL, slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	// Find the page to list
+	page := 1
+	if len(slackReq.CommandParams) == 2 {
+		page, err = strconv.Atoi(slackReq.CommandParams[1])
+		if err != nil || page < 1 {
+			return sendInvalidCommand(client, requester.lastContactURL)
+		}
+	}
+
+	box, err := s.DB.LoadBox(ctx, requester.trainer, boxID-1)
+	if err != nil {
+		return handlerError{user: "could not load the box", err: err}
+	}
+
+	pageCount := (len(box) + boxPageSize - 1) / boxPageSize
+	if pageCount == 0 {
+		pageCount = 1
+	}
+	if page > pageCount {
+		page = pageCount
+	}
+
+	type boxEntry struct {
+		Position int
+		Name     string
+		Level    int
+	}
+	templInfo := struct {
+		BoxID        int
+		Page         int
+		PageCount    int
+		NextPage     int
+		Entries      []boxEntry
+		SlashCommand string
+	}{
+		BoxID:        boxID,
+		Page:         page,
+		PageCount:    pageCount,
+		SlashCommand: slackReq.SlashCommand}
+	if page < pageCount {
+		templInfo.NextPage = page + 1
+	}
+	for i := (page - 1) * boxPageSize; i < len(box) && i < page*boxPageSize; i++ {
+		templInfo.Entries = append(templInfo.Entries, boxEntry{
+			Position: i + 1,
+			Name:     box[i].GetPokemon().DisplayName(),
+			Level:    box[i].GetPokemon().Level})
+	}
+
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     viewBoxTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate view box template", err: err}
+	}
+
+	return nil
+}
+
+// Deposit manages requests to move a Pokemon from the party to the PC.
+type Deposit struct {
+	Services
+}
+
+func (h *Deposit) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, err := strconv.Atoi(slackReq.CommandParams[0])
+	if err != nil {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+	if partySlotID < 1 || partySlotID > len(requester.pkmn) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPartySlotTemplate,
+			TemplInfo: partySlotID})
+		if err != nil {
+			return handlerError{user: "could not populate invalid party slot template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Trainers always need at least one Pokemon with them
+	if len(requester.pkmn) <= 1 {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     lastPokemonTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate last Pokemon template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Move the Pokemon to the PC
+	p := requester.pkmn[partySlotID-1]
+	boxID, err := boxPokemon(ctx, s.DB, requester.trainer, p, s.Clock.Now())
+	if errors.Cause(err) == errPCFull {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     pcFullTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate PC full template", err: err}
+		}
+		return nil // There is nothing else to do
+	} else if err != nil {
+		return handlerError{user: "could not put the Pokemon in the PC", err: err}
+	}
+	err = s.DB.DeletePokemon(ctx, requester.trainer, p.GetPokemon().UUID)
+	if err != nil {
+		return handlerError{user: "could not take the Pokemon out of the party", err: err}
+	}
+	requester.pkmn = append(requester.pkmn[:partySlotID-1], requester.pkmn[partySlotID:]...)
+
+	templInfo := struct {
+		PokemonName string
+		BoxID       int
+	}{
+		PokemonName: p.GetPokemon().DisplayName(),
+		BoxID:       boxID + 1}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     depositedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate deposited template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// Withdraw manages requests to move a Pokemon from the PC to the party.
+type Withdraw struct {
+	Services
+}
+
+func (h *Withdraw) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 2 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the box to withdraw from
+	boxID, ok, err := parseBoxID(client, requester.lastContactURL, slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+	box, err := s.DB.LoadBox(ctx, requester.trainer, boxID-1)
+	if err != nil {
+		return handlerError{user: "could not load the box", err: err}
+	}
+
+	// Find the Pokemon in the box
+	position, err := strconv.Atoi(slackReq.CommandParams[1])
+	if err != nil {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+	if position < 1 || position > len(box) {
+		templInfo := struct {
+			BoxID    int
+			Position int
+		}{
+			BoxID:    boxID,
+			Position: position}
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidBoxPositionTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate invalid box position template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	boxed := box[position-1]
+
+	// Move the Pokemon to the party
+	requester.pkmn, ok = givePokemon(requester.pkmn, s.DB.NewPokemon(*boxed.GetPokemon()))
+	if !ok {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     partyFullTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate party full template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	err = s.DB.DeleteBoxedPokemon(ctx, requester.trainer, boxed.GetPokemon().UUID)
+	if err != nil {
+		return handlerError{user: "could not take the Pokemon out of the PC", err: err}
+	}
+
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     withdrawnTemplate,
+		TemplInfo: boxed.GetPokemon().DisplayName()})
+	if err != nil {
+		return handlerError{user: "could not populate withdrawn template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
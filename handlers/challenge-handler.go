@@ -13,9 +13,9 @@ type Challenge struct {
 
 // sendInitialPkmnMessage sends a public message alerting everyone of the
 // Pokemon the trainer is starting the battle with, along with a sprite of that
-// Pokemon. It is assumed that the trainer starts the battle with the first
-// Pokemon in the party, like in the main series games.
-func (h *Challenge) sendInitialPkmnMessage(ctx context.Context, t *basicTrainerData) error {
+// Pokemon. The trainer starts the battle with the first Pokemon in the party
+// that hasn't fainted, like in the main series games.
+func (h *Challenge) sendInitialPkmnMessage(ctx context.Context, t *basicTrainerData, lead int) error {
 	// Load request-specific objects
 	client := ctx.Value("client").(messaging.Client)
 
@@ -26,11 +26,11 @@ func (h *Challenge) sendInitialPkmnMessage(ctx context.Context, t *basicTrainerD
 		Level       int
 	}{
 		TrainerName: t.trainer.GetTrainer().Name,
-		PokemonName: t.pkmn[0].GetPokemon().Name,
-		Level:       t.pkmn[0].GetPokemon().Level}
+		PokemonName: t.pkmn[lead].GetPokemon().DisplayName(),
+		Level:       t.pkmn[lead].GetPokemon().Level}
 	return messaging.SendTempl(client, t.lastContactURL, messaging.TemplMessage{
 		Public:    true,
-		Image:     t.pkmn[0].GetPokemon().SpriteURL,
+		Image:     t.pkmn[lead].GetPokemon().Sprite(),
 		Templ:     initialPokemonSendOutTemplate,
 		TemplInfo: initialPokemonTemplInfo})
 }
@@ -104,6 +104,18 @@ func (h *Challenge) runTask(ctx context.Context, s Services) error {
 		return nil // No more work to do
 	}
 
+	// Pokemon that have fainted can't battle until they're healed
+	requesterLeadSlot, ok := leadSlot(requester.pkmn)
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     allFaintedTemplate,
+			TemplInfo: slackReq.SlashCommand})
+		if err != nil {
+			return handlerError{user: "could not populate all fainted template", err: err}
+		}
+		return nil // No more work to do
+	}
+
 	// Load some info on the opponent
 	opponent, err := loadBasicTrainerData(ctx, s.DB, opponentUUID)
 	if err != nil {
@@ -148,16 +160,16 @@ func (h *Challenge) runTask(ctx context.Context, s Services) error {
 
 		// Make the battle info for each Pokemon
 		for _, p := range requester.pkmn {
-			pkmnBattleInfos = append(pkmnBattleInfos, pkmn.PokemonBattleInfo{
-				PkmnUUID: p.GetPokemon().UUID,
-				CurrHP:   pkmn.CalcOOBHP(p.GetPokemon().HP, *p.GetPokemon())})
+			pkmnBattleInfos = append(pkmnBattleInfos, p.GetPokemon().NewBattleInfo())
 		}
 		for _, p := range opponent.pkmn {
-			pkmnBattleInfos = append(pkmnBattleInfos, pkmn.PokemonBattleInfo{
-				PkmnUUID: p.GetPokemon().UUID,
-				CurrHP:   pkmn.CalcOOBHP(p.GetPokemon().HP, *p.GetPokemon())})
+			pkmnBattleInfos = append(pkmnBattleInfos, p.GetPokemon().NewBattleInfo())
 		}
 
+		// The opponent was checked for a Pokemon that can battle when they
+		// made the challenge and has been waiting since
+		opponentLeadSlot, _ := leadSlot(opponent.pkmn)
+
 		b.GetBattle().Mode = pkmn.StartedBattleMode // Start the battle
 
 		// Notify everyone that a battle has started
@@ -176,18 +188,40 @@ func (h *Challenge) runTask(ctx context.Context, s Services) error {
 		}
 
 		// Send message about the current trainer's first Pokemon
-		err = h.sendInitialPkmnMessage(ctx, requester)
+		err = h.sendInitialPkmnMessage(ctx, requester, requesterLeadSlot)
 		if err != nil {
 			// This request is non-critical, so it's okay if it fails
 			s.Log.Errorf(ctx, "while sending out information on the first starter: %s", err)
 		}
 		// Send message about the opponent's first Pokemon
-		err = h.sendInitialPkmnMessage(ctx, opponent)
+		err = h.sendInitialPkmnMessage(ctx, opponent, opponentLeadSlot)
 		if err != nil {
 			// This request is non-critical, so it's okay if it fails
 			s.Log.Errorf(ctx, "while sending out information on the first starter: %s", err)
 		}
 
+		// Apply the switch-in effects of both leading Pokemon's abilities.
+		// The opponent's Pokemon battle info comes after all of the
+		// requester's.
+		requesterLead := requester.pkmn[requesterLeadSlot].GetPokemon()
+		requesterLeadBI := &pkmnBattleInfos[requesterLeadSlot]
+		opponentLead := opponent.pkmn[opponentLeadSlot].GetPokemon()
+		opponentLeadBI := &pkmnBattleInfos[len(requester.pkmn)+opponentLeadSlot]
+		requester.trainer.GetTrainer().Pokedex.See(opponentLead.ID)
+		opponent.trainer.GetTrainer().Pokedex.See(requesterLead.ID)
+		ar := pkmn.RunSwitchInAbility(b.GetBattle(), requesterLead, opponentLead, requesterLeadBI, opponentLeadBI)
+		err = sendAbilityReport(client, requester.lastContactURL, true, ar,
+			requester.trainer.GetTrainer(), requesterLead, opponent.trainer.GetTrainer(), opponentLead)
+		if err != nil {
+			return handlerError{user: "could not populate ability report template", err: err}
+		}
+		ar = pkmn.RunSwitchInAbility(b.GetBattle(), opponentLead, requesterLead, opponentLeadBI, requesterLeadBI)
+		err = sendAbilityReport(client, requester.lastContactURL, true, ar,
+			opponent.trainer.GetTrainer(), opponentLead, requester.trainer.GetTrainer(), requesterLead)
+		if err != nil {
+			return handlerError{user: "could not populate ability report template", err: err}
+		}
+
 		// Get the battle info of the current trainer
 		requesterBI := p1BattleInfo
 		if requester.trainer.GetTrainer().UUID == b.GetBattle().P2 {
@@ -199,13 +233,17 @@ func (h *Challenge) runTask(ctx context.Context, s Services) error {
 			opponentBI = p2BattleInfo
 		}
 
+		// Both trainers lead with their first Pokemon that can battle
+		requesterBI.GetTrainerBattleInfo().CurrPkmnSlot = requesterLeadSlot
+		opponentBI.GetTrainerBattleInfo().CurrPkmnSlot = opponentLeadSlot
+
 		// Make action options for the current trainer
-		err = makeActionOptions(ctx, s, requester, requesterBI)
+		err = makeActionOptions(ctx, s, b.GetBattle(), requester, requesterBI)
 		if err != nil {
 			return handlerError{user: "could not send action options", err: err}
 		}
 		// Make action options for the opponent
-		err = makeActionOptions(ctx, s, opponent, opponentBI)
+		err = makeActionOptions(ctx, s, b.GetBattle(), opponent, opponentBI)
 		if err != nil {
 			return handlerError{user: "could not send action options", err: err}
 		}
@@ -257,6 +295,13 @@ func (h *Challenge) runTask(ctx context.Context, s Services) error {
 	if err != nil {
 		return handlerError{user: "could not save trainer", err: err}
 	}
+	if found {
+		// The opponent saw the requester's Pokemon
+		err = s.DB.SaveTrainer(ctx, opponent.trainer)
+		if err != nil {
+			return handlerError{user: "could not save opponent", err: err}
+		}
+	}
 	for _, pbi := range pkmnBattleInfos {
 		err = s.DB.SavePokemonBattleInfo(ctx, b, s.DB.NewPokemonBattleInfo(pbi))
 		if err != nil {
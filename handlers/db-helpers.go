@@ -160,6 +160,14 @@ func saveBattleData(ctx context.Context, db database.Database, bd *battleData) e
 		}
 	}
 
+	// Carry the Pokemon's HP and status over to the next battle
+	for i, pkmnBI := range bd.requester.pkmnBattleInfo {
+		bd.requester.pkmn[i].GetPokemon().KeepCondition(*pkmnBI.GetPokemonBattleInfo())
+	}
+	for i, pkmnBI := range bd.opponent.pkmnBattleInfo {
+		bd.opponent.pkmn[i].GetPokemon().KeepCondition(*pkmnBI.GetPokemonBattleInfo())
+	}
+
 	// Save the Pokemon
 	for _, pkmn := range bd.requester.pkmn {
 		err = db.SavePokemon(ctx, bd.requester.trainer, pkmn)
@@ -200,3 +208,41 @@ func loadMove(ctx context.Context, client messaging.Client, fetcher pokeapi.Fetc
 	}
 	return move, nil
 }
+
+// loadMoves fetches the info of all the moves the Pokemon knows.
+func loadMoves(ctx context.Context, client messaging.Client, fetcher pokeapi.Fetcher, p *pkmn.Pokemon) ([]pkmn.Move, error) {
+	var moves []pkmn.Move
+	for _, id := range p.MoveIDsAsSlice() {
+		move, err := loadMove(ctx, client, fetcher, id)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// loadUsableMoves fetches the info of all the moves the Pokemon knows that it
+// has PP left for. If it's out of PP for every move, it can only struggle.
+func loadUsableMoves(ctx context.Context, client messaging.Client, fetcher pokeapi.Fetcher, p *pkmn.Pokemon) ([]pkmn.Move, error) {
+	moves, err := loadMoves(ctx, client, fetcher, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var usable []pkmn.Move
+	for _, move := range moves {
+		if p.HasPP(move) {
+			usable = append(usable, move)
+		}
+	}
+	if len(usable) == 0 {
+		struggle, err := loadMove(ctx, client, fetcher, pkmn.StruggleMoveID)
+		if err != nil {
+			return nil, err
+		}
+		usable = append(usable, struggle)
+	}
+
+	return usable, nil
+}
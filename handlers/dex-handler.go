@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+	"github.com/velovix/snoreslacks/pokeapi"
+)
+
+// ViewDex manages requests to look at the trainer's Pokedex. With no
+// parameters, how much of each region's Pokedex is complete is shown.
+// Otherwise, the entry of the species with the given name or National Pokedex
+// ID is shown.
+type ViewDex struct {
+	Services
+}
+
+func (h *ViewDex) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	dex := &requester.trainer.GetTrainer().Pokedex
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) > 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	if len(slackReq.CommandParams) == 0 {
+		// Show how complete the Pokedex is in each region
+		type regionEntry struct {
+			Name   string
+			Seen   int
+			Caught int
+			Total  int
+		}
+		templInfo := struct {
+			Regions      []regionEntry
+			Seen         int
+			Caught       int
+			Total        int
+			SlashCommand string
+		}{
+			Seen:         len(dex.Seen),
+			Caught:       len(dex.Caught),
+			Total:        pkmn.NationalDexSize,
+			SlashCommand: slackReq.SlashCommand}
+		for r := pkmn.Region(0); int(r) < pkmn.RegionCount; r++ {
+			templInfo.Regions = append(templInfo.Regions, regionEntry{
+				Name:   strings.Title(r.Name()),
+				Seen:   dex.SeenIn(r),
+				Caught: dex.CaughtIn(r),
+				Total:  r.SpeciesCount()})
+		}
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     viewDexTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate view Pokedex template", err: err}
+		}
+		return nil
+	}
+
+	// Find the species to show, by ID or by name
+	param := slackReq.CommandParams[0]
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		species, err := pokeapi.FetchPokemonSpeciesByName(param, client)
+		if errors.Cause(err) == pokeapi.ErrNoSuchSpecies {
+			id = 0
+		} else if err != nil {
+			return handlerError{user: "could not fetch Pokemon species information", err: err}
+		} else {
+			id = species.ID
+		}
+	}
+	if id < 1 || id > pkmn.NationalDexSize {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     noSuchSpeciesTemplate,
+			TemplInfo: param})
+		if err != nil {
+			return handlerError{user: "could not populate no such species template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Entries are only filled in once the species has been seen
+	if !dex.HasSeen(id) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     speciesNotSeenTemplate,
+			TemplInfo: id})
+		if err != nil {
+			return handlerError{user: "could not populate species not seen template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Build the entry from PokeAPI data
+	apiPkmn, err := s.Fetcher.FetchPokemon(ctx, client, id)
+	if err != nil {
+		return handlerError{user: "could not fetch Pokemon information", err: err}
+	}
+	species, err := s.Fetcher.FetchPokemonSpecies(ctx, client, id)
+	if err != nil {
+		return handlerError{user: "could not fetch Pokemon species information", err: err}
+	}
+	entry, err := pokeapi.NewDexEntry(apiPkmn, species)
+	if err != nil {
+		return handlerError{user: "could not fetch Pokemon species information", err: err}
+	}
+
+	templInfo := struct {
+		pkmn.DexEntry
+		Caught bool
+	}{
+		DexEntry: entry,
+		Caught:   dex.HasCaught(id)}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     dexEntryTemplate,
+		TemplInfo: templInfo,
+		Image:     entry.SpriteURL})
+	if err != nil {
+		return handlerError{user: "could not populate Pokedex entry template", err: err}
+	}
+
+	return nil
+}
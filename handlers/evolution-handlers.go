@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+	"github.com/velovix/snoreslacks/pokeapi"
+	"golang.org/x/net/context"
+)
+
+// evolvingPokemon returns the Pokemon in the trainer's party that is waiting
+// to evolve, or false if there is none.
+func evolvingPokemon(t *basicTrainerData) (database.Pokemon, bool) {
+	for _, partyMember := range t.pkmn {
+		if partyMember.GetPokemon().PendingEvolution != 0 {
+			return partyMember, true
+		}
+	}
+	return nil, false
+}
+
+// finishEvolution continues leveling up the trainer's party now that the
+// pending evolution has been dealt with, then saves the trainer.
+func finishEvolution(ctx context.Context, s Services, requester *basicTrainerData) error {
+	// Continue the process of leveling up the party
+	problemSlot, err := levelUpPartyIfPossible(ctx, s, requester)
+	if err != nil {
+		return handlerError{user: "could not continue leveling up the party", err: err}
+	}
+	if problemSlot == -1 {
+		// The process is complete
+		requester.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
+	}
+
+	// Save data if all went well
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save basic trainer data", err: err}
+	}
+
+	return nil
+}
+
+// evolvePokemon turns the given Pokemon into the species with the given ID and
+// lets its trainer know.
+func evolvePokemon(ctx context.Context, s Services, t *basicTrainerData, pk database.Pokemon, speciesID int) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	// Build the evolved form of the Pokemon from PokeAPI data. Regional
+	// variants evolve into the same regional variant of the evolved species.
+	apiPkmn, err := pokeapi.FetchVariety(ctx, client, s.Fetcher, speciesID, pk.GetPokemon().Form)
+	if err != nil {
+		return errors.Wrap(err, "while fetching the evolved Pokemon")
+	}
+	evolved, err := pokeapi.NewPokemon(ctx, client, s.Fetcher, apiPkmn, pk.GetPokemon().Level)
+	if err != nil {
+		return errors.Wrap(err, "while creating the evolved Pokemon")
+	}
+
+	// Evolve the Pokemon
+	oldName := pk.GetPokemon().DisplayName()
+	pk.GetPokemon().Evolve(evolved, pokeapi.RegularAbilities(apiPkmn))
+	t.trainer.GetTrainer().Pokedex.Catch(evolved.ID)
+
+	// Let the trainer know
+	templInfo := struct {
+		OldName string
+		NewName string
+	}{
+		OldName: oldName,
+		NewName: pk.GetPokemon().Name}
+	err = messaging.SendTempl(client, t.lastContactURL, messaging.TemplMessage{
+		Type:      messaging.Good,
+		Templ:     evolvedTemplate,
+		TemplInfo: templInfo,
+		Image:     pk.GetPokemon().Sprite()})
+	if err != nil {
+		return errors.Wrap(err, "while populating the evolved template")
+	}
+
+	return nil
+}
+
+// evolveIfTraded evolves the given Pokemon if being traded makes it evolve,
+// using up the item it's holding if the evolution needs one. This should be
+// run on a Pokemon as soon as it's traded, with t being its new trainer. True
+// is returned if the Pokemon evolved.
+func evolveIfTraded(ctx context.Context, s Services, t *basicTrainerData, pk database.Pokemon) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	evolutions, err := pokeapi.FetchEvolutions(ctx, client, s.Fetcher, pk.GetPokemon().ID)
+	if err != nil {
+		return false, errors.Wrap(err, "while checking for evolutions")
+	}
+	evo, ok := pkmn.TradeEvolution(*pk.GetPokemon(), evolutions, s.Clock)
+	if !ok {
+		return false, nil
+	}
+
+	if evo.HeldItem != pkmn.NoItem {
+		pk.GetPokemon().HeldItem = pkmn.NoItem
+	}
+	err = evolvePokemon(ctx, s, t, pk, evo.SpeciesID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Evolve lets the Pokemon that is waiting to evolve do so.
+type Evolve struct {
+}
+
+func (h *Evolve) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Get the Pokemon in question
+	pk, ok := evolvingPokemon(requester)
+	if !ok {
+		return handlerError{user: "none of your Pokémon are evolving", err: errors.New("evolving trainer has no evolving Pokemon")}
+	}
+
+	err := evolvePokemon(ctx, s, requester, pk, pk.GetPokemon().PendingEvolution)
+	if err != nil {
+		return handlerError{user: "could not evolve the Pokemon", err: err}
+	}
+
+	return finishEvolution(ctx, s, requester)
+}
+
+// CancelEvolution stops the Pokemon that is waiting to evolve from evolving.
+type CancelEvolution struct {
+}
+
+func (h *CancelEvolution) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Get the Pokemon in question
+	pk, ok := evolvingPokemon(requester)
+	if !ok {
+		return handlerError{user: "none of your Pokémon are evolving", err: errors.New("evolving trainer has no evolving Pokemon")}
+	}
+
+	pk.GetPokemon().CancelEvolution()
+
+	// Let the trainer know
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     evolutionCancelledTemplate,
+		TemplInfo: pk.GetPokemon().DisplayName()})
+	if err != nil {
+		return handlerError{user: "could not populate evolution cancelled template", err: err}
+	}
+
+	return finishEvolution(ctx, s, requester)
+}
+
+// useEvolutionItem uses an item like an evolution stone on the given Pokemon
+// outside of battle, evolving it if the item has an effect on it.
+func useEvolutionItem(ctx context.Context, s Services, requester *basicTrainerData, pk database.Pokemon, item pkmn.Item) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	// Find out if the item makes the Pokemon evolve
+	evolutions, err := pokeapi.FetchEvolutions(ctx, client, s.Fetcher, pk.GetPokemon().ID)
+	if err != nil {
+		return handlerError{user: "could not check for evolutions", err: err}
+	}
+	evo, ok := pkmn.ItemEvolution(*pk.GetPokemon(), evolutions, item, s.Clock)
+	if !ok {
+		templInfo := struct {
+			PokemonName string
+			ItemName    string
+		}{
+			PokemonName: pk.GetPokemon().DisplayName(),
+			ItemName:    item.Name()}
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     itemHadNoEffectTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate item had no effect template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Use up the item and evolve the Pokemon
+	err = requester.trainer.GetTrainer().TakeItem(item)
+	if err != nil {
+		return handlerError{user: "could not take the item from the bag", err: err}
+	}
+	err = evolvePokemon(ctx, s, requester, pk, evo.SpeciesID)
+	if err != nil {
+		return handlerError{user: "could not evolve the Pokemon", err: err}
+	}
+
+	return nil
+}
@@ -121,13 +121,14 @@ func (h *Forfeit) runTask(ctx context.Context, s Services) error {
 	}
 
 	// Delete the trainer if it is temporary
-	if battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType {
+	if battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType ||
+		battleData.opponent.trainer.GetTrainer().Type == pkmn.GymLeaderTrainerType {
 		// The battle is over and the trainer is one-time-use. It's time to
 		// destroy him or her.
 		s.Log.Infof(ctx, "deleting temporary trainer %v because the battle is over", battleData.opponent.trainer.GetTrainer().UUID)
 		err = s.DB.PurgeTrainer(ctx, battleData.opponent.trainer.GetTrainer().UUID)
 		if err != nil {
-			return handlerError{user: "could not purge the wild trainer", err: err}
+			return handlerError{user: "could not purge the temporary trainer", err: err}
 		}
 	}
 
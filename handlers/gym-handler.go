@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/satori/go.uuid"
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+	"github.com/velovix/snoreslacks/pokeapi"
+)
+
+// GymBattle handles requests to challenge the next gym leader of a region.
+type GymBattle struct {
+	Services
+}
+
+func (h *GymBattle) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Assert that the trainer is currently in waiting mode
+	if requester.trainer.GetTrainer().Mode != pkmn.WaitingTrainerMode {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     gymWhenInWrongModeTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate gym when in wrong mode template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Pokemon that have fainted can't battle until they're healed
+	lead, ok := leadSlot(requester.pkmn)
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     allFaintedTemplate,
+			TemplInfo: slackReq.SlashCommand})
+		if err != nil {
+			return handlerError{user: "could not populate all fainted template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the region the trainer wants to challenge a gym in
+	regionName := strings.ToLower(slackReq.CommandParams[0])
+	region, ok := pkmn.NameToRegion(regionName)
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     noSuchRegionTemplate,
+			TemplInfo: regionName})
+		if err != nil {
+			return handlerError{user: "could not populate no such region template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Find the gym leader the trainer has to beat next
+	leader, ok := pkmn.NextGymLeader(requester.trainer.GetTrainer(), region)
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     allGymsBeatenTemplate,
+			TemplInfo: strings.Title(region.Name())})
+		if err != nil {
+			return handlerError{user: "could not populate all gyms beaten template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Put the trainer in battle mode
+	requester.trainer.GetTrainer().Mode = pkmn.BattlingTrainerMode
+
+	// Create the gym leader's team
+	team := make([]database.Pokemon, 0, len(leader.Team))
+	for _, member := range leader.Team {
+		// Get PokeAPI Data on the Pokemon
+		apiPkmn, err := s.Fetcher.FetchPokemon(ctx, client, member.ID)
+		if err != nil {
+			return handlerError{user: "unable to fetch Pokemon information", err: err}
+		}
+		// Create the pkmn.Pokemon from the PokeAPI data
+		p, err := pokeapi.NewPokemon(ctx, client, s.Fetcher, apiPkmn, member.Level)
+		if err != nil {
+			return handlerError{user: "unable to fetch Pokemon information", err: err}
+		}
+
+		// Gym leader Pokemon always know the same moves
+		moves := make([]int, 4)
+		copy(moves, member.MoveIDs)
+		p.Move1, p.Move2, p.Move3, p.Move4 = moves[0], moves[1], moves[2], moves[3]
+
+		team = append(team, s.DB.NewPokemon(p))
+	}
+
+	// Create an ephemeral trainer to act as the gym leader
+	leaderTrainer := s.DB.NewTrainer(pkmn.Trainer{
+		UUID:     uuid.NewV4().String(),
+		Name:     leader.Name,
+		Mode:     pkmn.BattlingTrainerMode,
+		Type:     pkmn.GymLeaderTrainerType,
+		Strategy: leader.Strategy,
+		Bag:      append([]pkmn.BagEntry(nil), leader.Items...)})
+
+	// Create a battle between the trainer and the gym leader
+	b := s.DB.NewBattle(pkmn.Battle{
+		P1:        requester.trainer.GetTrainer().UUID,
+		P2:        leaderTrainer.GetTrainer().UUID,
+		Mode:      pkmn.StartedBattleMode,
+		GymBattle: true,
+		GymRegion: region})
+	// Create trainer battle info
+	trainerBattleInfo := s.DB.NewTrainerBattleInfo(pkmn.TrainerBattleInfo{
+		TrainerUUID:  requester.trainer.GetTrainer().UUID,
+		CurrPkmnSlot: lead})
+	leaderBattleInfo := s.DB.NewTrainerBattleInfo(pkmn.TrainerBattleInfo{TrainerUUID: leaderTrainer.GetTrainer().UUID})
+
+	s.Log.Infof(ctx, "creating a new gym battle: %+v", b)
+
+	// Create Pokemon battle info for all the gym leader's and the trainer's
+	// Pokemon
+	leaderPkmnBIs := make([]database.PokemonBattleInfo, 0, len(team))
+	for _, p := range team {
+		leaderPkmnBIs = append(leaderPkmnBIs, s.DB.NewPokemonBattleInfo(p.GetPokemon().NewBattleInfo()))
+	}
+	trainerPkmnBIs := make([]database.PokemonBattleInfo, 0, len(requester.pkmn))
+	for _, p := range requester.pkmn {
+		trainerPkmnBIs = append(trainerPkmnBIs, s.DB.NewPokemonBattleInfo(p.GetPokemon().NewBattleInfo()))
+	}
+
+	// Send message telling the trainer that the gym battle started
+	leaderLead := team[0].GetPokemon()
+	requester.trainer.GetTrainer().Pokedex.See(leaderLead.ID)
+	templInfo := struct {
+		LeaderName  string
+		PokemonName string
+		Level       int
+	}{
+		LeaderName:  leader.Name,
+		PokemonName: leaderLead.Name,
+		Level:       leaderLead.Level}
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     gymBattleStartedTemplate,
+		TemplInfo: templInfo,
+		Image:     leaderLead.Sprite()})
+	if err != nil {
+		return handlerError{user: "could not populate gym battle started template", err: err}
+	}
+
+	// Apply the switch-in effects of both leading Pokemon's abilities
+	leadPkmn := requester.pkmn[lead].GetPokemon()
+	leadPkmnBI := trainerPkmnBIs[lead].GetPokemonBattleInfo()
+	leaderLeadBI := leaderPkmnBIs[0].GetPokemonBattleInfo()
+	ar := pkmn.RunSwitchInAbility(b.GetBattle(), leadPkmn, leaderLead, leadPkmnBI, leaderLeadBI)
+	err = sendAbilityReport(client, requester.lastContactURL, false, ar,
+		requester.trainer.GetTrainer(), leadPkmn, leaderTrainer.GetTrainer(), leaderLead)
+	if err != nil {
+		return handlerError{user: "could not populate ability report template", err: err}
+	}
+	ar = pkmn.RunSwitchInAbility(b.GetBattle(), leaderLead, leadPkmn, leaderLeadBI, leadPkmnBI)
+	err = sendAbilityReport(client, requester.lastContactURL, false, ar,
+		leaderTrainer.GetTrainer(), leaderLead, requester.trainer.GetTrainer(), leadPkmn)
+	if err != nil {
+		return handlerError{user: "could not populate ability report template", err: err}
+	}
+
+	// Send the trainer their action options
+	err = makeActionOptions(ctx, s, b.GetBattle(), requester, trainerBattleInfo)
+	if err != nil {
+		return handlerError{user: "could not send action options", err: err}
+	}
+
+	// Save database objects
+	err = s.DB.SaveTrainerBattleInfo(ctx, b, trainerBattleInfo)
+	if err != nil {
+		return handlerError{user: "could not save trainer battle info", err: err}
+	}
+	err = s.DB.SaveTrainerBattleInfo(ctx, b, leaderBattleInfo)
+	if err != nil {
+		return handlerError{user: "could not save gym leader battle info", err: err}
+	}
+	for _, bi := range append(leaderPkmnBIs, trainerPkmnBIs...) {
+		err = s.DB.SavePokemonBattleInfo(ctx, b, bi)
+		if err != nil {
+			return handlerError{user: "could not save Pokemon battle info", err: err}
+		}
+	}
+	err = s.DB.SaveParty(ctx, leaderTrainer, team)
+	if err != nil {
+		return handlerError{user: "could not save the gym leader's party", err: err}
+	}
+	err = s.DB.SaveBattle(ctx, b)
+	if err != nil {
+		return handlerError{user: "could not save battle", err: err}
+	}
+	err = s.DB.SaveTrainer(ctx, leaderTrainer)
+	if err != nil {
+		return handlerError{user: "could not save gym leader", err: err}
+	}
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer", err: err}
+	}
+
+	return nil
+}
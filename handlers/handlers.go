@@ -9,6 +9,7 @@ import (
 	"github.com/velovix/snoreslacks/database"
 	"github.com/velovix/snoreslacks/logging"
 	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
 	"github.com/velovix/snoreslacks/pokeapi"
 	"github.com/velovix/snoreslacks/tasking"
 	"golang.org/x/net/context"
@@ -148,6 +149,8 @@ type Services struct {
 	ClientCreator messaging.ClientCreator
 	Fetcher       pokeapi.Fetcher
 	WorkQueue     tasking.Queue
+	Clock         pkmn.Clock
+	RNG           pkmn.RNG
 }
 
 // decodeSlackReq decodes a Slack request from the given HTTP request.
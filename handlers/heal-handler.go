@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/messaging"
+)
+
+// Heal handles requests to take the party to the Pokemon Center, which
+// restores the HP and PP of every Pokemon in it and cures their status
+// conditions.
+type Heal struct {
+	Services
+}
+
+func (h *Heal) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	// Trainers can only visit the Pokemon Center every so often
+	if !t.CanHeal(s.Clock) {
+		minutes := int(t.NextHeal(s.Clock).Minutes()) + 1
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     tooSoonToHealTemplate,
+			TemplInfo: minutes})
+		if err != nil {
+			return handlerError{user: "could not populate too soon to heal template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Heal the party
+	t.LastHeal = s.Clock.Now()
+	for _, p := range requester.pkmn {
+		p.GetPokemon().Heal()
+	}
+
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     healedTemplate,
+		TemplInfo: nil})
+	if err != nil {
+		return handlerError{user: "could not populate healed template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
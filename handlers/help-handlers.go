@@ -93,3 +93,47 @@ func (h *ForgetMoveHelp) runTask(ctx context.Context, s Services) error {
 
 	return nil
 }
+
+// EvolvingHelp sends help information to the user when they are deciding
+// whether or not to let a Pokemon evolve.
+type EvolvingHelp struct {
+}
+
+func (h *EvolvingHelp) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Send the templated info
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     evolvingHelpTemplate,
+		TemplInfo: slackReq.SlashCommand})
+	if err != nil {
+		return handlerError{user: "could not populate evolving help template", err: err}
+	}
+
+	return nil
+}
+
+// ReleasingHelp sends help information to the user when they are deciding
+// whether or not to release a Pokemon.
+type ReleasingHelp struct {
+}
+
+func (h *ReleasingHelp) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Send the templated info
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     releasingHelpTemplate,
+		TemplInfo: slackReq.SlashCommand})
+	if err != nil {
+		return handlerError{user: "could not populate releasing help template", err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,490 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// findBagItem finds the item with the given name and checks that the trainer
+// has at least one of it. If either isn't the case, the trainer is told so and
+// false is returned.
+func findBagItem(client messaging.Client, url string, t *pkmn.Trainer, name string) (pkmn.Item, bool, error) {
+	item, ok := pkmn.NameToItem(name)
+	if !ok {
+		err := messaging.SendTempl(client, url, messaging.TemplMessage{
+			Templ:     noSuchItemTemplate,
+			TemplInfo: name})
+		if err != nil {
+			return pkmn.NoItem, false, handlerError{user: "could not populate no such item template", err: err}
+		}
+		return pkmn.NoItem, false, nil
+	}
+
+	if t.ItemCount(item) <= 0 {
+		err := messaging.SendTempl(client, url, messaging.TemplMessage{
+			Templ:     noneOfItemTemplate,
+			TemplInfo: item.Name()})
+		if err != nil {
+			return pkmn.NoItem, false, handlerError{user: "could not populate none of item template", err: err}
+		}
+		return pkmn.NoItem, false, nil
+	}
+
+	return item, true, nil
+}
+
+// ViewBag manages requests to see the items in the trainer's bag.
+type ViewBag struct {
+	Services
+}
+
+func (h *ViewBag) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	templInfo := struct {
+		Money int
+		Bag   []pkmn.BagEntry
+	}{
+		Money: requester.trainer.GetTrainer().Money,
+		Bag:   requester.trainer.GetTrainer().Bag}
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     viewBagTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate view bag template", err: err}
+	}
+
+	return nil
+}
+
+// UseItem handles requests to use an item from the bag in battle. This
+// function will queue up the item to be used once both trainers finish
+// selecting the action they will take.
+type UseItem struct {
+	Services
+}
+
+func (h *UseItem) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+	battleData := ctx.Value("battle data").(*battleData)
+
+	// Assert that the trainer is in battle mode
+	if requester.trainer.GetTrainer().Mode != pkmn.BattlingTrainerMode {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Assert that all necessary data is in the battle data object
+	if !battleData.isComplete() {
+		return handlerError{user: "could not load battle data", err: errors.New("incomplete battle data object")}
+	}
+
+	// Pokemon that have to keep using a move can't do anything else
+	forced, err := sendIfMoveForced(ctx, s, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a forced move", err: err}
+	}
+	if forced {
+		return nil // There is nothing else to do
+	}
+
+	// A fainted Pokemon has to be replaced before anything else can be done
+	mustReplace, err := sendIfMustReplace(ctx, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a fainted Pokemon", err: err}
+	}
+	if mustReplace {
+		return nil // There is nothing else to do
+	}
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 1 && len(slackReq.CommandParams) != 2 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the item
+	item, ok, err := findBagItem(client, requester.lastContactURL, battleData.requester.trainer.GetTrainer(), slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	// Only medicine can be used through this command
+	switch item.Category() {
+	case pkmn.BallItemCategory:
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     ballOutsideCatchTemplate,
+			TemplInfo: item.Name()})
+		if err != nil {
+			return handlerError{user: "could not populate ball outside catch template", err: err}
+		}
+		return nil // There is nothing else to do
+	case pkmn.HeldItemCategory:
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     heldItemOnlyTemplate,
+			TemplInfo: item.Name()})
+		if err != nil {
+			return handlerError{user: "could not populate held item only template", err: err}
+		}
+		return nil // There is nothing else to do
+	case pkmn.EvolutionItemCategory:
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     evolutionItemInBattleTemplate,
+			TemplInfo: item.Name()})
+		if err != nil {
+			return handlerError{user: "could not populate evolution item in battle template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Find the party slot to use the item on, defaulting to the Pokemon
+	// currently in battle
+	partySlotID := battleData.requester.battleInfo.GetTrainerBattleInfo().CurrPkmnSlot + 1
+	if len(slackReq.CommandParams) == 2 {
+		partySlotID, err = strconv.Atoi(slackReq.CommandParams[1])
+		if err != nil {
+			return sendInvalidCommand(client, requester.lastContactURL)
+		}
+	}
+
+	// Check if the party slot ID is valid
+	if partySlotID < 1 || partySlotID > len(battleData.requester.pkmn) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPartySlotTemplate,
+			TemplInfo: partySlotID})
+		if err != nil {
+			return handlerError{user: "could not populate invalid party slot template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Set up the next battle action to be an item action
+	battleData.requester.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
+	battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
+		Type:   pkmn.ItemBattleActionType,
+		Val:    int(item),
+		Target: partySlotID - 1}
+
+	// Send confirmation that the item was received
+	templInfo := struct {
+		ItemName    string
+		PokemonName string
+	}{
+		ItemName:    item.Name(),
+		PokemonName: battleData.requester.pkmn[partySlotID-1].GetPokemon().DisplayName()}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     itemConfirmationTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate item confirmation template", err: err}
+	}
+
+	// Get a turn processor ready to do any required processing
+	tp := turnProcessor{Services: s}
+
+	var battleOver bool
+	// Do any work required to get the opponent ready for the turn to be
+	// processed
+	ready, err := preprocessTurn(ctx, s, battleData)
+	if err != nil {
+		return handlerError{user: "could not do preprocessing on the current turn", err: err}
+	}
+	if ready {
+		// The opponent is ready and the turn may be processed
+		battleOver, err = tp.process(ctx, battleData)
+		if err != nil {
+			return handlerError{user: "could not process the current turn", err: err}
+		}
+	}
+
+	// Save data if all has gone well
+	err = saveBattleData(ctx, s.DB, battleData)
+	if err != nil {
+		return handlerError{user: "could not save battle session", err: err}
+	}
+	if battleOver && (battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType ||
+		battleData.opponent.trainer.GetTrainer().Type == pkmn.GymLeaderTrainerType) {
+		// The battle is over and the trainer is one-time-use. It's time to
+		// destroy him.
+		err = s.DB.PurgeTrainer(ctx, battleData.opponent.trainer.GetTrainer().UUID)
+		if err != nil {
+			return handlerError{user: "could not purge the temporary trainer", err: err}
+		}
+	}
+	if battleOver {
+		// Delete the battle if it has ended
+		err = s.DB.PurgeBattle(ctx, battleData.battle.GetBattle().P1, battleData.battle.GetBattle().P2)
+		if err != nil {
+			return handlerError{user: "could not delete a battle", err: err}
+		}
+	}
+
+	return nil
+}
+
+// UseItemOOB handles requests to use an item from the bag on a Pokemon in the
+// party outside of battle. Medicine heals the Pokemon and evolution items make
+// it evolve.
+type UseItemOOB struct {
+	Services
+}
+
+func (h *UseItemOOB) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 2 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the item
+	item, ok, err := findBagItem(client, requester.lastContactURL, requester.trainer.GetTrainer(), slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	// Only medicine and evolution items can be used outside of battle
+	if item.Category() != pkmn.MedicineItemCategory && item.Category() != pkmn.EvolutionItemCategory {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notUsableOutsideBattleTemplate,
+			TemplInfo: item.Name()})
+		if err != nil {
+			return handlerError{user: "could not populate not usable outside battle template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, err := strconv.Atoi(slackReq.CommandParams[1])
+	if err != nil {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+	if partySlotID < 1 || partySlotID > len(requester.pkmn) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPartySlotTemplate,
+			TemplInfo: partySlotID})
+		if err != nil {
+			return handlerError{user: "could not populate invalid party slot template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	pk := requester.pkmn[partySlotID-1]
+
+	if item.Category() == pkmn.MedicineItemCategory {
+		err = useMedicine(ctx, requester, pk, item)
+	} else {
+		err = useEvolutionItem(ctx, s, requester, pk, item)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// useMedicine uses a medicine item on the given Pokemon outside of battle. The
+// Pokemon keeps the HP and status it ends up with, and the item is only used
+// up if it had an effect.
+func useMedicine(ctx context.Context, requester *basicTrainerData, pk database.Pokemon, item pkmn.Item) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	// Use the item on the Pokemon's condition as if it were in battle
+	p := pk.GetPokemon()
+	pBI := p.NewBattleInfo()
+	ir, err := pkmn.UseMedicine(item, p, &pBI)
+	if err != nil {
+		return handlerError{user: "could not use item", err: err}
+	}
+	if ir.Activated {
+		err = requester.trainer.GetTrainer().TakeItem(item)
+		if err != nil {
+			return handlerError{user: "you don't have any " + item.Name(), err: err}
+		}
+		p.KeepCondition(pBI)
+	}
+
+	// Let the trainer know what happened
+	templInfo := struct {
+		pkmn.ItemReport
+		TrainerName string
+		ItemName    string
+		PokemonName string
+	}{
+		ItemReport:  ir,
+		TrainerName: requester.trainer.GetTrainer().Name,
+		ItemName:    item.Name(),
+		PokemonName: p.DisplayName()}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     itemUsedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate item used template", err: err}
+	}
+
+	return nil
+}
+
+// GiveItem handles requests to give an item from the bag to a Pokemon in the
+// party to hold.
+type GiveItem struct {
+	Services
+}
+
+func (h *GiveItem) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 2 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the item
+	item, ok, err := findBagItem(client, requester.lastContactURL, requester.trainer.GetTrainer(), slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, err := strconv.Atoi(slackReq.CommandParams[1])
+	if err != nil {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+	if partySlotID < 1 || partySlotID > len(requester.pkmn) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPartySlotTemplate,
+			TemplInfo: partySlotID})
+		if err != nil {
+			return handlerError{user: "could not populate invalid party slot template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	p := requester.pkmn[partySlotID-1].GetPokemon()
+
+	// Move the item from the bag to the Pokemon, putting whatever it was
+	// holding before back in the bag
+	err = requester.trainer.GetTrainer().TakeItem(item)
+	if err != nil {
+		return handlerError{user: "could not take the item from the bag", err: err}
+	}
+	oldItem := p.HeldItem
+	if oldItem != pkmn.NoItem {
+		requester.trainer.GetTrainer().AddItem(oldItem, 1)
+	}
+	p.HeldItem = item
+
+	templInfo := struct {
+		PokemonName string
+		ItemName    string
+		OldItemName string
+	}{
+		PokemonName: p.DisplayName(),
+		ItemName:    item.Name(),
+		OldItemName: oldItem.Name()}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     itemGivenTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate item given template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// TakeItem handles requests to take the item a Pokemon in the party is holding
+// and put it back in the bag.
+type TakeItem struct {
+	Services
+}
+
+func (h *TakeItem) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, err := strconv.Atoi(slackReq.CommandParams[0])
+	if err != nil {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+	if partySlotID < 1 || partySlotID > len(requester.pkmn) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPartySlotTemplate,
+			TemplInfo: partySlotID})
+		if err != nil {
+			return handlerError{user: "could not populate invalid party slot template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	p := requester.pkmn[partySlotID-1].GetPokemon()
+
+	if p.HeldItem == pkmn.NoItem {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notHoldingItemTemplate,
+			TemplInfo: p.DisplayName()})
+		if err != nil {
+			return handlerError{user: "could not populate not holding item template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Put the item back in the bag
+	item := p.HeldItem
+	p.HeldItem = pkmn.NoItem
+	requester.trainer.GetTrainer().AddItem(item, 1)
+
+	templInfo := struct {
+		PokemonName string
+		ItemName    string
+	}{
+		PokemonName: p.DisplayName(),
+		ItemName:    item.Name()}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     itemTakenTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate item taken template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
@@ -4,10 +4,8 @@ import (
 	"strconv"
 
 	"github.com/pkg/errors"
-	"github.com/velovix/snoreslacks/database"
 	"github.com/velovix/snoreslacks/messaging"
 	"github.com/velovix/snoreslacks/pkmn"
-	"github.com/velovix/snoreslacks/pokeapi"
 	"golang.org/x/net/context"
 )
 
@@ -21,32 +19,25 @@ func (h *NoForgetMove) runTask(ctx context.Context, s Services) error {
 	requester := ctx.Value("requesting trainer").(*basicTrainerData)
 
 	// Get the Pokemon in question
-	var pk database.Pokemon
-	for _, partyMember := range requester.pkmn {
-		// Find the first Pokemon that is waiting to level up, which is always
-		// the one we're currently talking about
-		if partyMember.GetPokemon().ReadyToLevelUp() {
-			pk = partyMember
-			break
-		}
+	pk, ok := pokemonLearningMove(requester)
+	if !ok {
+		return handlerError{user: "none of your Pokémon are learning a move", err: errors.New("trainer forgetting a move has no Pokemon learning one")}
 	}
+	newMoveID, _ := pk.GetPokemon().PendingMove()
 
 	// Load information on the move that won't be learned
-	learnableMoves, err := pokeapi.FetchLevelLearnableMoveIDs(ctx, client, s.Fetcher, pk.GetPokemon().ID)
-	if err != nil {
-		return handlerError{user: "failed to fetch learnable moves", err: err}
-	}
-	move, err := loadMove(ctx, client, s.Fetcher, learnableMoves[pk.GetPokemon().Level+1][0])
+	move, err := loadMove(ctx, client, s.Fetcher, newMoveID)
 	if err != nil {
 		return handlerError{user: "failed to load move info", err: err}
 	}
 
 	// Let the user know the Pokemon gave up on learning the move
+	pk.GetPokemon().GiveUpPendingMove()
 	templInfo := struct {
 		PokemonName string
 		MoveName    string
 	}{
-		PokemonName: pk.GetPokemon().Name,
+		PokemonName: pk.GetPokemon().DisplayName(),
 		MoveName:    move.Name}
 	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
 		Templ:     giveUpLearningMoveTemplate,
@@ -55,18 +46,11 @@ func (h *NoForgetMove) runTask(ctx context.Context, s Services) error {
 		return handlerError{user: "could not populate give up learning move template", err: err}
 	}
 
-	// Level up the Pokemon manually to show that all conflicts have been
-	// resolved
-	pk.GetPokemon().Level += 1
-	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
-		Templ:     levelUpTemplate,
-		TemplInfo: pk.GetPokemon()})
-	if err != nil {
-		return handlerError{user: "could not populate level up template", err: err}
-	}
-
 	// Continue the process of leveling up the party
 	problemSlot, err := levelUpPartyIfPossible(ctx, s, requester)
+	if err != nil {
+		return handlerError{user: "could not continue leveling up the party", err: err}
+	}
 	if problemSlot == -1 {
 		// The process is complete
 		requester.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
@@ -104,15 +88,11 @@ func (h *ForgetMove) runTask(ctx context.Context, s Services) error {
 	}
 
 	// Get the Pokemon in question
-	var pk database.Pokemon
-	for _, partyMember := range requester.pkmn {
-		// Find the first Pokemon that is waiting to level up, which is always
-		// the one we're currently talking about
-		if partyMember.GetPokemon().ReadyToLevelUp() {
-			pk = partyMember
-			break
-		}
+	pk, ok := pokemonLearningMove(requester)
+	if !ok {
+		return handlerError{user: "none of your Pokémon are learning a move", err: errors.New("trainer forgetting a move has no Pokemon learning one")}
 	}
+	newMoveID, _ := pk.GetPokemon().PendingMove()
 
 	// Check if the given slot ID is valid
 	if replacedMoveSlotID < 1 || replacedMoveSlotID > pk.GetPokemon().MoveCount() {
@@ -126,11 +106,7 @@ func (h *ForgetMove) runTask(ctx context.Context, s Services) error {
 	}
 
 	// Load information on the move that will be replacing the selected move
-	learnableMoves, err := pokeapi.FetchLevelLearnableMoveIDs(ctx, client, s.Fetcher, pk.GetPokemon().ID)
-	if err != nil {
-		return handlerError{user: "failed to fetch learnable moves", err: err}
-	}
-	newMove, err := loadMove(ctx, client, s.Fetcher, learnableMoves[pk.GetPokemon().Level+1][0])
+	newMove, err := loadMove(ctx, client, s.Fetcher, newMoveID)
 	if err != nil {
 		return handlerError{user: "failed to load move info", err: err}
 	}
@@ -141,7 +117,7 @@ func (h *ForgetMove) runTask(ctx context.Context, s Services) error {
 	}
 
 	// Replace the move
-	err = pk.GetPokemon().ReplaceMove(replacedMoveSlotID, newMove.ID)
+	err = pk.GetPokemon().LearnPendingMove(replacedMoveSlotID)
 	if err != nil {
 		return handlerError{user: "unable to replace move", err: errors.Wrap(err, "while replacing move")}
 	}
@@ -152,7 +128,7 @@ func (h *ForgetMove) runTask(ctx context.Context, s Services) error {
 		OldMoveName string
 		NewMoveName string
 	}{
-		PokemonName: pk.GetPokemon().Name,
+		PokemonName: pk.GetPokemon().DisplayName(),
 		OldMoveName: oldMove.Name,
 		NewMoveName: newMove.Name}
 	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
@@ -162,18 +138,11 @@ func (h *ForgetMove) runTask(ctx context.Context, s Services) error {
 		return handlerError{user: "failed to populate replaced move template", err: err}
 	}
 
-	// Level up the Pokemon manually to show that all conflicts have been
-	// resolved
-	pk.GetPokemon().Level += 1
-	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
-		Templ:     levelUpTemplate,
-		TemplInfo: pk.GetPokemon()})
-	if err != nil {
-		return handlerError{user: "could not populate level up template", err: err}
-	}
-
 	// Continue the process of leveling up the party
 	problemSlot, err := levelUpPartyIfPossible(ctx, s, requester)
+	if err != nil {
+		return handlerError{user: "could not continue leveling up the party", err: err}
+	}
 	if problemSlot == -1 {
 		// The process is complete
 		requester.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/gob"
 	"net/http"
+	"strings"
 
 	"github.com/velovix/snoreslacks/database"
 	"github.com/velovix/snoreslacks/messaging"
@@ -116,10 +117,50 @@ func (h *Main) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			h.Log.Infof(ctx, "'%s' is looking for a list of commands", slackReq.Username)
 			h.WorkQueue.Add(ctx, WaitingHelpURL, slackReqBlob.Bytes())
 		case "PARTY":
+			if len(slackReq.CommandParams) > 0 && strings.ToUpper(slackReq.CommandParams[0]) == "SWAP" {
+				// The user wants to reorder their party
+
+				h.Log.Infof(ctx, "'%s' wants to reorder their party", slackReq.Username)
+				h.WorkQueue.Add(ctx, SwapPartyURL, slackReqBlob.Bytes())
+				break
+			}
+
 			// The user wants to see their party
 
 			h.Log.Infof(ctx, "'%s' wants to see their party", slackReq.Username)
 			h.WorkQueue.Add(ctx, ViewPartyURL, slackReqBlob.Bytes())
+		case "NICKNAME":
+			// The user wants to give a Pokemon a nickname
+
+			h.Log.Infof(ctx, "'%s' wants to nickname a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, NicknameURL, slackReqBlob.Bytes())
+		case "RELEASE":
+			// The user wants to release a Pokemon
+
+			h.Log.Infof(ctx, "'%s' wants to release a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, ReleaseURL, slackReqBlob.Bytes())
+		case "TRADE":
+			if len(slackReq.CommandParams) == 1 {
+				switch strings.ToUpper(slackReq.CommandParams[0]) {
+				case "CONFIRM":
+					// The user is agreeing to their trade
+
+					h.Log.Infof(ctx, "'%s' is confirming their trade", slackReq.Username)
+					h.WorkQueue.Add(ctx, ConfirmTradeURL, slackReqBlob.Bytes())
+					return
+				case "CANCEL":
+					// The user is backing out of their trade
+
+					h.Log.Infof(ctx, "'%s' is cancelling their trade", slackReq.Username)
+					h.WorkQueue.Add(ctx, CancelTradeURL, slackReqBlob.Bytes())
+					return
+				}
+			}
+
+			// The user wants to offer a Pokemon to another trainer
+
+			h.Log.Infof(ctx, "'%s' wants to trade a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, TradeURL, slackReqBlob.Bytes())
 		case "BATTLE":
 			// The user wants to battle
 
@@ -130,6 +171,79 @@ func (h *Main) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 			h.Log.Infof(ctx, "'%s' is looking to encounter a wild Pokemon", slackReq.Username)
 			h.WorkQueue.Add(ctx, WildEncounterURL, slackReqBlob.Bytes())
+		case "GYM":
+			// The user wants to challenge a gym leader
+
+			h.Log.Infof(ctx, "'%s' is looking to challenge a gym leader", slackReq.Username)
+			h.WorkQueue.Add(ctx, GymBattleURL, slackReqBlob.Bytes())
+		case "BAG":
+			// The user wants to see their bag
+
+			h.Log.Infof(ctx, "'%s' wants to see their bag", slackReq.Username)
+			h.WorkQueue.Add(ctx, ViewBagURL, slackReqBlob.Bytes())
+		case "MART":
+			if len(slackReq.CommandParams) > 0 && strings.ToUpper(slackReq.CommandParams[0]) == "BUY" {
+				// The user wants to buy something
+
+				h.Log.Infof(ctx, "'%s' wants to buy an item", slackReq.Username)
+				h.WorkQueue.Add(ctx, BuyItemURL, slackReqBlob.Bytes())
+				break
+			}
+
+			// The user wants to see what's for sale
+
+			h.Log.Infof(ctx, "'%s' wants to see the Poke Mart", slackReq.Username)
+			h.WorkQueue.Add(ctx, ViewMartURL, slackReqBlob.Bytes())
+		case "GIVE":
+			// The user wants to give an item to a Pokemon
+
+			h.Log.Infof(ctx, "'%s' wants to give an item to a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, GiveItemURL, slackReqBlob.Bytes())
+		case "TAKE":
+			// The user wants to take an item from a Pokemon
+
+			h.Log.Infof(ctx, "'%s' wants to take an item from a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, TakeItemURL, slackReqBlob.Bytes())
+		case "USE":
+			// The user wants to use an item on a Pokemon outside of battle
+
+			h.Log.Infof(ctx, "'%s' wants to use an item on a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, UseItemOOBURL, slackReqBlob.Bytes())
+		case "WALK":
+			// The user wants to take their party for a walk
+
+			h.Log.Infof(ctx, "'%s' wants to take their party for a walk", slackReq.Username)
+			h.WorkQueue.Add(ctx, WalkURL, slackReqBlob.Bytes())
+		case "HEAL":
+			// The user wants to take their party to the Pokemon Center
+
+			h.Log.Infof(ctx, "'%s' wants to heal their party", slackReq.Username)
+			h.WorkQueue.Add(ctx, HealURL, slackReqBlob.Bytes())
+		case "DEX":
+			// The user wants to look at their Pokedex
+
+			h.Log.Infof(ctx, "'%s' wants to see their Pokedex", slackReq.Username)
+			h.WorkQueue.Add(ctx, ViewDexURL, slackReqBlob.Bytes())
+		case "TRAVEL":
+			// The user wants to travel to another area
+
+			h.Log.Infof(ctx, "'%s' wants to travel", slackReq.Username)
+			h.WorkQueue.Add(ctx, TravelURL, slackReqBlob.Bytes())
+		case "BOX":
+			// The user wants to see what's in their PC
+
+			h.Log.Infof(ctx, "'%s' wants to see their PC", slackReq.Username)
+			h.WorkQueue.Add(ctx, ViewBoxURL, slackReqBlob.Bytes())
+		case "DEPOSIT":
+			// The user wants to put a Pokemon in their PC
+
+			h.Log.Infof(ctx, "'%s' wants to deposit a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, DepositURL, slackReqBlob.Bytes())
+		case "WITHDRAW":
+			// The user wants to take a Pokemon out of their PC
+
+			h.Log.Infof(ctx, "'%s' wants to withdraw a Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, WithdrawURL, slackReqBlob.Bytes())
 		}
 	case pkmn.ForgetMoveTrainerMode:
 		// The trainer is currently deciding whether or not to replace an
@@ -152,6 +266,44 @@ func (h *Main) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			h.Log.Infof(ctx, "'%s' wants to forget an existing move in favor of a new one", slackReq.Username)
 			h.WorkQueue.Add(ctx, ForgetMoveURL, slackReqBlob.Bytes())
 		}
+	case pkmn.EvolvingTrainerMode:
+		// The trainer is deciding whether or not to let a Pokemon evolve
+		switch slackReq.CommandName {
+		default:
+			// The user doesn't know what to do
+
+			h.Log.Infof(ctx, "'%s' is looking for a list of commands while in evolving mode", slackReq.Username)
+			h.WorkQueue.Add(ctx, EvolvingHelpURL, slackReqBlob.Bytes())
+		case "EVOLVE":
+			// The user is letting their Pokemon evolve
+
+			h.Log.Infof(ctx, "'%s' is letting their Pokemon evolve", slackReq.Username)
+			h.WorkQueue.Add(ctx, EvolveURL, slackReqBlob.Bytes())
+		case "CANCEL":
+			// The user is stopping their Pokemon from evolving
+
+			h.Log.Infof(ctx, "'%s' is stopping their Pokemon from evolving", slackReq.Username)
+			h.WorkQueue.Add(ctx, CancelEvolutionURL, slackReqBlob.Bytes())
+		}
+	case pkmn.ReleasingTrainerMode:
+		// The trainer is deciding whether or not to release a Pokemon
+		switch slackReq.CommandName {
+		default:
+			// The user doesn't know what to do
+
+			h.Log.Infof(ctx, "'%s' is looking for a list of commands while in releasing mode", slackReq.Username)
+			h.WorkQueue.Add(ctx, ReleasingHelpURL, slackReqBlob.Bytes())
+		case "YES":
+			// The user is releasing their Pokemon
+
+			h.Log.Infof(ctx, "'%s' is releasing their Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, ConfirmReleaseURL, slackReqBlob.Bytes())
+		case "NO":
+			// The user is keeping their Pokemon
+
+			h.Log.Infof(ctx, "'%s' is keeping their Pokemon", slackReq.Username)
+			h.WorkQueue.Add(ctx, CancelReleaseURL, slackReqBlob.Bytes())
+		}
 	case pkmn.BattlingTrainerMode:
 		// The trainer is battling or waiting to battle
 
@@ -218,6 +370,16 @@ func (h *Main) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 				h.Log.Infof(ctx, "'%s' wants to catch the Pokemon", slackReq.Username)
 				h.WorkQueue.Add(ctx, CatchPokemonURL, slackReqBlob.Bytes())
+			case "BAG":
+				// The user wants to see their bag
+
+				h.Log.Infof(ctx, "'%s' wants to see their bag", slackReq.Username)
+				h.WorkQueue.Add(ctx, ViewBagURL, slackReqBlob.Bytes())
+			case "ITEM":
+				// The user wants to use an item from their bag
+
+				h.Log.Infof(ctx, "'%s' wants to use an item", slackReq.Username)
+				h.WorkQueue.Add(ctx, UseItemURL, slackReqBlob.Bytes())
 			}
 		}
 	}
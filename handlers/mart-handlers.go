@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// ViewMart manages requests to see the items sold at the Poke Mart.
+type ViewMart struct {
+	Services
+}
+
+func (h *ViewMart) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	type martEntry struct {
+		Name   string
+		Price  int
+		Badges int
+		Locked bool
+	}
+	templInfo := struct {
+		Money        int
+		Items        []martEntry
+		SlashCommand string
+	}{
+		Money:        t.Money,
+		SlashCommand: slackReq.SlashCommand}
+	for _, mi := range pkmn.MartCatalogue() {
+		templInfo.Items = append(templInfo.Items, martEntry{
+			Name:   mi.Item.Name(),
+			Price:  mi.Price,
+			Badges: mi.Badges,
+			Locked: !t.CanBuy(mi)})
+	}
+
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     viewMartTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate view mart template", err: err}
+	}
+
+	return nil
+}
+
+// BuyItem manages requests to buy items from the Poke Mart. The items go
+// straight into the trainer's bag and the purchase is recorded in their
+// ledger.
+type BuyItem struct {
+	Services
+}
+
+func (h *BuyItem) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	// Check if the command looks correct. The first parameter is "buy" and
+	// the count is optional
+	if len(slackReq.CommandParams) != 2 && len(slackReq.CommandParams) != 3 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+	count := 1
+	if len(slackReq.CommandParams) == 3 {
+		var err error
+		count, err = strconv.Atoi(slackReq.CommandParams[2])
+		if err != nil {
+			return sendInvalidCommand(client, requester.lastContactURL)
+		}
+	}
+	if count < 1 || count > pkmn.MaxPurchaseCount {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPurchaseCountTemplate,
+			TemplInfo: pkmn.MaxPurchaseCount})
+		if err != nil {
+			return handlerError{user: "could not populate invalid purchase count template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Find the item in the catalogue
+	name := strings.ToLower(slackReq.CommandParams[1])
+	item, ok := pkmn.NameToItem(name)
+	var mi pkmn.MartItem
+	if ok {
+		mi, ok = pkmn.FindMartItem(item)
+	}
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notSoldAtMartTemplate,
+			TemplInfo: name})
+		if err != nil {
+			return handlerError{user: "could not populate not sold at mart template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	if !t.CanBuy(mi) {
+		templInfo := struct {
+			ItemName string
+			Badges   int
+		}{
+			ItemName: item.Name(),
+			Badges:   mi.Badges}
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     martItemLockedTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate mart item locked template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Pay for the items
+	cost := mi.Price * count
+	entry, err := t.Spend(cost, pkmn.PurchaseLedgerReason,
+		"bought "+strconv.Itoa(count)+" "+item.Name(), s.Clock.Now())
+	if err == pkmn.ErrNotEnoughMoney {
+		templInfo := struct {
+			ItemName string
+			Count    int
+			Cost     int
+			Money    int
+		}{
+			ItemName: item.Name(),
+			Count:    count,
+			Cost:     cost,
+			Money:    t.Money}
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notEnoughMoneyTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate not enough money template", err: err}
+		}
+		return nil // There is nothing else to do
+	} else if err != nil {
+		return handlerError{user: "could not pay for the items", err: err}
+	}
+
+	// The items, the new balance and the ledger entry are all saved in the
+	// same transaction, so a purchase can't half happen
+	t.AddItem(item, count)
+	err = s.DB.SaveLedgerEntry(ctx, requester.trainer, s.DB.NewLedgerEntry(entry))
+	if err != nil {
+		return handlerError{user: "could not record the purchase", err: err}
+	}
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	templInfo := struct {
+		ItemName string
+		Count    int
+		Cost     int
+		Money    int
+	}{
+		ItemName: item.Name(),
+		Count:    count,
+		Cost:     cost,
+		Money:    t.Money}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     itemsBoughtTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate items bought template", err: err}
+	}
+
+	return nil
+}
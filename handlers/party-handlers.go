@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// parsePartySlotID extracts a party slot ID from the given command parameter.
+// If the parameter isn't a slot in the trainer's party, the trainer is told
+// so and false is returned. Party slot IDs start at 1.
+func parsePartySlotID(client messaging.Client, t *basicTrainerData, param string) (int, bool, error) {
+	partySlotID, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, false, sendInvalidCommand(client, t.lastContactURL)
+	}
+	if partySlotID < 1 || partySlotID > len(t.pkmn) {
+		err = messaging.SendTempl(client, t.lastContactURL, messaging.TemplMessage{
+			Templ:     invalidPartySlotTemplate,
+			TemplInfo: partySlotID})
+		if err != nil {
+			return 0, false, handlerError{user: "could not populate invalid party slot template", err: err}
+		}
+		return 0, false, nil
+	}
+
+	return partySlotID, true, nil
+}
+
+// releasingPokemon returns the Pokemon in the trainer's party that the trainer
+// is deciding whether to release, or false if there is none.
+func releasingPokemon(t *basicTrainerData) (database.Pokemon, int, bool) {
+	uuid := t.trainer.GetTrainer().PendingRelease
+	for slot, partyMember := range t.pkmn {
+		if uuid != "" && partyMember.GetPokemon().UUID == uuid {
+			return partyMember, slot, true
+		}
+	}
+	return nil, -1, false
+}
+
+// SwapParty manages requests to swap the positions of two Pokemon in the
+// party. The Pokemon in the first slot leads in battle.
+type SwapParty struct {
+	Services
+}
+
+func (h *SwapParty) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct. The first parameter is "swap"
+	if len(slackReq.CommandParams) != 3 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Extract the party slot IDs from the command
+	a, ok, err := parsePartySlotID(client, requester, slackReq.CommandParams[1])
+	if err != nil || !ok {
+		return err
+	}
+	b, ok, err := parsePartySlotID(client, requester, slackReq.CommandParams[2])
+	if err != nil || !ok {
+		return err
+	}
+
+	// Swap the Pokemon
+	requester.pkmn[a-1], requester.pkmn[b-1] = requester.pkmn[b-1], requester.pkmn[a-1]
+
+	templInfo := struct {
+		FirstName  string
+		FirstSlot  int
+		SecondName string
+		SecondSlot int
+	}{
+		FirstName:  requester.pkmn[a-1].GetPokemon().DisplayName(),
+		FirstSlot:  a,
+		SecondName: requester.pkmn[b-1].GetPokemon().DisplayName(),
+		SecondSlot: b}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     partySwappedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate party swapped template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// Nickname manages requests to give a Pokemon in the party a nickname. Giving
+// no nickname makes the Pokemon go by its species name again.
+type Nickname struct {
+	Services
+}
+
+func (h *Nickname) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) < 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, ok, err := parsePartySlotID(client, requester, slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+	p := requester.pkmn[partySlotID-1].GetPokemon()
+
+	// Nicknames may have spaces in them
+	oldName := p.DisplayName()
+	err = p.SetNickname(strings.Join(slackReq.CommandParams[1:], " "))
+	if err != nil {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     nicknameTooLongTemplate,
+			TemplInfo: pkmn.MaxNicknameLength})
+		if err != nil {
+			return handlerError{user: "could not populate nickname too long template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	templInfo := struct {
+		OldName     string
+		NewName     string
+		SpeciesName string
+		Nicknamed   bool
+	}{
+		OldName:     oldName,
+		NewName:     p.DisplayName(),
+		SpeciesName: p.Name,
+		Nicknamed:   p.Nickname != ""}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     nicknamedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate nicknamed template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// Release manages requests to release a Pokemon in the party into the wild.
+// The trainer has to confirm the release before it happens.
+type Release struct {
+	Services
+}
+
+func (h *Release) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, ok, err := parsePartySlotID(client, requester, slackReq.CommandParams[0])
+	if err != nil || !ok {
+		return err
+	}
+
+	// Trainers always need at least one Pokemon with them
+	if len(requester.pkmn) <= 1 {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     lastPokemonTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate last Pokemon template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Wait for the trainer to confirm
+	p := requester.pkmn[partySlotID-1].GetPokemon()
+	requester.trainer.GetTrainer().PendingRelease = p.UUID
+	requester.trainer.GetTrainer().Mode = pkmn.ReleasingTrainerMode
+
+	templInfo := struct {
+		PokemonName  string
+		SlashCommand string
+	}{
+		PokemonName:  p.DisplayName(),
+		SlashCommand: slackReq.SlashCommand}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     confirmReleaseTemplate,
+		TemplInfo: templInfo,
+		Image:     p.Sprite()})
+	if err != nil {
+		return handlerError{user: "could not populate confirm release template", err: err}
+	}
+
+	// Save the trainer
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// ConfirmRelease releases the Pokemon the trainer asked to release. Any item
+// it was holding is put back in the trainer's bag.
+type ConfirmRelease struct {
+	Services
+}
+
+func (h *ConfirmRelease) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	// Get the Pokemon in question
+	pk, slot, ok := releasingPokemon(requester)
+	if !ok {
+		// Let the trainer get back to what they were doing
+		t.PendingRelease = ""
+		t.Mode = pkmn.WaitingTrainerMode
+		err := s.DB.SaveTrainer(ctx, requester.trainer)
+		if err != nil {
+			return handlerError{user: "could not save trainer data", err: err}
+		}
+		return handlerError{user: "none of your Pokémon are being released", err: errors.New("releasing trainer has no Pokemon to release")}
+	}
+	p := pk.GetPokemon()
+
+	// Hold on to whatever the Pokemon was holding
+	heldItem := p.HeldItem
+	if heldItem != pkmn.NoItem {
+		t.AddItem(heldItem, 1)
+	}
+
+	// Release the Pokemon
+	err := s.DB.DeletePokemon(ctx, requester.trainer, p.UUID)
+	if err != nil {
+		return handlerError{user: "could not release the Pokemon", err: err}
+	}
+	requester.pkmn = append(requester.pkmn[:slot], requester.pkmn[slot+1:]...)
+	t.PendingRelease = ""
+	t.Mode = pkmn.WaitingTrainerMode
+
+	templInfo := struct {
+		PokemonName string
+		ItemName    string
+	}{
+		PokemonName: p.DisplayName()}
+	if heldItem != pkmn.NoItem {
+		templInfo.ItemName = heldItem.Name()
+	}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     releasedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate released template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
+
+// CancelRelease keeps the Pokemon the trainer was thinking of releasing.
+type CancelRelease struct {
+	Services
+}
+
+func (h *CancelRelease) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	// The Pokemon may have left the party some other way in the meantime,
+	// so it's fine if it can't be found
+	pk, _, ok := releasingPokemon(requester)
+	t.PendingRelease = ""
+	t.Mode = pkmn.WaitingTrainerMode
+
+	if ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     releaseCancelledTemplate,
+			TemplInfo: pk.GetPokemon().DisplayName()})
+		if err != nil {
+			return handlerError{user: "could not populate release cancelled template", err: err}
+		}
+	}
+
+	// Save the trainer
+	err := s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
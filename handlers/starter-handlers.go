@@ -75,6 +75,10 @@ func (h *NewTrainer) runTask(ctx context.Context, s Services) error {
 		SinnohEncounterLevel: 1,
 		UnovaEncounterLevel:  1,
 		KalosEncounterLevel:  1,
+		// Every trainer starts their journey with some basic supplies
+		Bag: []pkmn.BagEntry{
+			{Item: pkmn.PotionItem, Count: 5},
+			{Item: pkmn.PokeBallItem, Count: 10}},
 	})
 
 	s.Log.Infof(ctx, "created a new trainer: %+v", *requester.GetTrainer())
@@ -151,6 +155,7 @@ func (h *ChoosingStarter) runTask(ctx context.Context, s Services) error {
 				// something seriously wrong
 				return handlerError{user: "starting trainer already has the maximum amount of Pokemon", err: err}
 			}
+			requester.trainer.GetTrainer().Pokedex.Catch(val.ID)
 			requester.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
 			validStarter = true
 			break
@@ -39,6 +39,15 @@ func (h *SwitchPokemon) runTask(ctx context.Context, s Services) error {
 		return handlerError{user: "could not load battle data", err: errors.New("incomplete battle data object")}
 	}
 
+	// Pokemon that have to keep using a move can't do anything else
+	forced, err := sendIfMoveForced(ctx, s, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a forced move", err: err}
+	}
+	if forced {
+		return nil // There is nothing else to do
+	}
+
 	// Check if the command looks correct
 	if len(slackReq.CommandParams) != 1 {
 		return sendInvalidCommand(client, requester.lastContactURL)
@@ -84,36 +93,46 @@ func (h *SwitchPokemon) runTask(ctx context.Context, s Services) error {
 		return nil // There is nothing else to do
 	}
 
-	// Set up the next battle action to be a switch Pokemon action
-	battleData.requester.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
-	battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
-		Type: pkmn.SwitchBattleActionType,
-		Val:  partySlotID - 1}
-
-	// Send confirmation that the switch was received
-	err = messaging.SendTempl(client, battleData.requester.lastContactURL, messaging.TemplMessage{
-		Templ:     switchConfirmationTemplate,
-		TemplInfo: requester.pkmn[partySlotID-1].GetPokemon().Name,
-		Public:    false})
-	if err != nil {
-		return handlerError{user: "could not populate switch confirmation template", err: err}
-	}
-
 	// Get a turn processor ready to do any required processing
 	tp := turnProcessor{Services: s}
 
 	var battleOver bool
-	// Do any work required to get the opponent ready for the turn to be
-	// processed
-	ready, err := preprocessTurn(ctx, s, battleData)
-	if err != nil {
-		return handlerError{user: "could not do preprocessing on the current turn", err: err}
-	}
-	if ready {
-		// The opponent is ready and the turn may be processed
-		battleOver, err = tp.process(ctx, battleData)
+	if battleData.requester.battleInfo.GetTrainerBattleInfo().MustReplace {
+		// Replacing a fainted Pokemon happens right away and doesn't use up
+		// the trainer's turn
+		public := battleData.opponent.trainer.GetTrainer().Type == pkmn.HumanTrainerType
+		battleOver, err = tp.replaceFainted(ctx, public, battleData, battleData.requester, battleData.opponent, partySlotID-1)
+		if err != nil {
+			return handlerError{user: "could not replace the fainted Pokemon", err: err}
+		}
+	} else {
+		// Set up the next battle action to be a switch Pokemon action
+		battleData.requester.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
+		battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
+			Type: pkmn.SwitchBattleActionType,
+			Val:  partySlotID - 1}
+
+		// Send confirmation that the switch was received
+		err = messaging.SendTempl(client, battleData.requester.lastContactURL, messaging.TemplMessage{
+			Templ:     switchConfirmationTemplate,
+			TemplInfo: requester.pkmn[partySlotID-1].GetPokemon().DisplayName(),
+			Public:    false})
 		if err != nil {
-			return handlerError{user: "could not process the current turn", err: err}
+			return handlerError{user: "could not populate switch confirmation template", err: err}
+		}
+
+		// Do any work required to get the opponent ready for the turn to be
+		// processed
+		ready, err := preprocessTurn(ctx, s, battleData)
+		if err != nil {
+			return handlerError{user: "could not do preprocessing on the current turn", err: err}
+		}
+		if ready {
+			// The opponent is ready and the turn may be processed
+			battleOver, err = tp.process(ctx, battleData)
+			if err != nil {
+				return handlerError{user: "could not process the current turn", err: err}
+			}
 		}
 	}
 
@@ -122,12 +141,13 @@ func (h *SwitchPokemon) runTask(ctx context.Context, s Services) error {
 	if err != nil {
 		return handlerError{user: "could not save battle session", err: err}
 	}
-	if battleOver && battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType {
+	if battleOver && (battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType ||
+		battleData.opponent.trainer.GetTrainer().Type == pkmn.GymLeaderTrainerType) {
 		// The battle is over and the trainer is one-time-use. It's time to
 		// destroy him.
 		err = s.DB.PurgeTrainer(ctx, battleData.opponent.trainer.GetTrainer().UUID)
 		if err != nil {
-			return handlerError{user: "could not purge the wild trainer", err: err}
+			return handlerError{user: "could not purge the temporary trainer", err: err}
 		}
 	}
 	if battleOver {
@@ -69,7 +69,7 @@ var invalidStarterTemplate *template.Template
 // wants to view their party.
 var viewPartyInBattleTemplateText = `
 {{ range . }}
-	*{{ .Name }}* (No. {{ .ID }})
+	*{{ .Name }}* (No. {{ .ID }}){{ if .Form }} - {{ .Form }} form{{ end }}{{ if .Shiny }} - shiny{{ end }}
 {{ printf "\u0060\u0060\u0060" -}}
 IN-BATTLE STATS
   HP     : {{ .CurrHP }} / {{ .HP }}
@@ -80,6 +80,13 @@ BASE STATS
   HP    : {{ printf "%3d" .HP        }}    Att   : {{ printf "%3d" .Attack }}
   Def   : {{ printf "%3d" .Defense   }}    SpAtt : {{ printf "%3d" .SpAttack }}
   SpDef : {{ printf "%3d" .SpDefense }}    Speed : {{ printf "%3d" .Speed }}
+  Nature: {{ .Nature }}
+  Abil. : {{ .Ability }}
+  Item  : {{ .HeldItem }}
+  IVs   : {{ .IVQuality }}
+{{- if .OriginalTrainer }}
+  OT    : {{ .OriginalTrainer }}
+{{- end }}
 {{ printf "\u0060\u0060\u0060" }}
 {{ end }}
 `
@@ -89,13 +96,24 @@ var viewPartyInBattleTemplate *template.Template
 // to view their party.
 var viewPartyTemplateText = `
 {{ range . }}
-	*{{ .Name }}* (No. {{ .ID }})
+	*{{ .Name }}* (No. {{ .ID }}){{ if .Form }} - {{ .Form }} form{{ end }}{{ if .Shiny }} - shiny{{ end }}
 {{ printf "\u0060\u0060\u0060" -}}
+CONDITION
+  HP     : {{ .CurrHP }} / {{ .HP }}
+  Status : {{ .StatusCondition }}
+
 BASE STATS
   Level : {{ printf "%3d" .Level     }}    Types : {{ .Type1 }} {{ .Type2 }}
   HP    : {{ printf "%3d" .HP        }}    Att   : {{ printf "%3d" .Attack }}
   Def   : {{ printf "%3d" .Defense   }}    SpAtt : {{ printf "%3d" .SpAttack }}
   SpDef : {{ printf "%3d" .SpDefense }}    Speed : {{ printf "%3d" .Speed }}
+  Nature: {{ .Nature }}
+  Abil. : {{ .Ability }}
+  Item  : {{ .HeldItem }}
+  IVs   : {{ .IVQuality }}
+{{- if .OriginalTrainer }}
+  OT    : {{ .OriginalTrainer }}
+{{- end }}
 {{ printf "\u0060\u0060\u0060" }}
 {{ end }}
 `
@@ -104,9 +122,16 @@ var viewPartyTemplate *template.Template
 type viewSinglePokemonTemplateInfo struct {
 	Name            string
 	ID              int
+	Form            string
+	Shiny           bool
 	Level           int
 	Type1           string
 	Type2           string
+	Nature          string
+	Ability         string
+	HeldItem        string
+	OriginalTrainer string
+	IVQuality       string
 	HP              int
 	CurrHP          int
 	Attack          int
@@ -129,7 +154,67 @@ View the list of Pokémon you have in your party, including their stats and othe
 Request a battle with a trainer that has the given username. The user has to be a trainer. The user can accept by using this command with your username.
 
 {{ . }} *wild*
-Jump into a wild Pokémon encounter! More wild Pokemon become available to you as you progress through the game.
+Jump into a wild Pokémon encounter in the area you're in.
+
+{{ . }} *travel* [_region_ _area_]
+See the areas you can travel to, or travel to the given area to find different wild Pokémon. Earning badges in a region opens up more of its areas.
+
+{{ . }} *gym* _region_
+Challenge the next gym leader of the given region (kanto, johto, hoenn, sinnoh, unova or kalos) for a badge.
+
+{{ . }} *bag*
+View the items in your bag and how much money you have.
+
+{{ . }} *mart*
+See the items for sale at the Poké Mart. Beating trainers and gym leaders earns you money to spend there.
+
+{{ . }} *mart buy* _item_ [_count_]
+Buy one or more of an item from the Poké Mart. It goes straight into your bag.
+
+{{ . }} *give* _item_ _slot_
+Give an item from your bag to the Pokémon in the given party slot to hold.
+
+{{ . }} *take* _slot_
+Take the item the Pokémon in the given party slot is holding and put it back in your bag.
+
+{{ . }} *use* _item_ _slot_
+Use an item from your bag, like an evolution stone, on the Pokémon in the given party slot.
+
+{{ . }} *walk*
+Take your party for a walk. Pokémon grow friendlier with every walk.
+
+{{ . }} *heal*
+Take your party to the Pokémon Center to restore their HP and PP and cure their status conditions.
+
+{{ . }} *dex* [_name_ or _number_]
+See how much of the Pokédex you've completed in each region, or look up the entry of a Pokémon you've seen.
+
+{{ . }} *box* [_box_] [_page_]
+See how full each box in your PC is, or list the Pokémon in the given box.
+
+{{ . }} *deposit* _slot_
+Put the Pokémon in the given party slot in your PC.
+
+{{ . }} *withdraw* _box_ _n_
+Move the n-th Pokémon in the given box of your PC to your party.
+
+{{ . }} *party swap* _slot_ _slot_
+Swap the places of the Pokémon in the given party slots. The Pokémon in the first slot is sent out first in battle.
+
+{{ . }} *nickname* _slot_ [_name_]
+Give the Pokémon in the given party slot a nickname, or remove its nickname if no name is given.
+
+{{ . }} *release* _slot_
+Release the Pokémon in the given party slot into the wild. You'll be asked to confirm first.
+
+{{ . }} *trade* _username_ _slot_
+Offer the Pokémon in the given party slot to the trainer with the given username. They can offer one of their own Pokémon in return by using this command with your username.
+
+{{ . }} *trade confirm*
+Agree to the trade you're in. The Pokémon are exchanged once both trainers have confirmed.
+
+{{ . }} *trade cancel*
+Back out of the trade you're in.
 `
 var waitingHelpTemplate *template.Template
 
@@ -158,8 +243,14 @@ Uses the move with the given ID
 {{ . }} *switch* _slot_
 Switch to the Pokémon with the given ID.
 
-{{ . }} *catch*
-Throws a Pokéball at the Pokemon. Just don't do this to Pokémon that already have an owner!
+{{ . }} *catch* _[ball]_
+Throws a ball from your bag at the Pokemon, a poke-ball by default. Just don't do this to Pokémon that already have an owner!
+
+{{ . }} *bag*
+View the items in your bag.
+
+{{ . }} *item* _item_ _[slot]_
+Use an item from your bag on the Pokémon in the given party slot, or the Pokémon currently in battle by default.
 
 {{ . }} *forfeit*
 Leave the battle. This counts as a loss for you.
@@ -180,6 +271,34 @@ Keep all existing moves and give up learning the new one.
 `
 var forgetMoveHelpTemplate *template.Template
 
+// Evolving help template. This template will be shown when the trainer is
+// looking for a list of commands while deciding whether or not to let a
+// Pokemon evolve.
+var evolvingHelpTemplateText = `
+You are choosing whether or not to let one of your Pokémon evolve.
+
+{{ . }} evolve
+Let the Pokémon evolve.
+
+{{ . }} cancel
+Stop the Pokémon from evolving. It will try again the next time it levels up.
+`
+var evolvingHelpTemplate *template.Template
+
+// Releasing help template. This template will be shown when the trainer is
+// looking for a list of commands while deciding whether or not to release a
+// Pokemon.
+var releasingHelpTemplateText = `
+You are choosing whether or not to release one of your Pokémon.
+
+{{ . }} yes
+Release the Pokémon. This can't be undone!
+
+{{ . }} no
+Keep the Pokémon.
+`
+var releasingHelpTemplate *template.Template
+
 // No such trainer exists template. This template will be shown when the
 // trainer wants to interact with another trainer that isn't registred.
 var noSuchTrainerExistsTemplateText = `
@@ -234,13 +353,24 @@ You will be switching to {{ . }} next turn.
 `
 var switchConfirmationTemplate *template.Template
 
+// Item confirmation template. This template will be shown when a trainer
+// chooses to use an item from their bag.
+var itemConfirmationTemplateText = `
+You will be using a {{ .ItemName }} on {{ .PokemonName }} next turn.
+`
+var itemConfirmationTemplate *template.Template
+
 // Action options template. Shows the battle options a trainer has.
 var actionOptionsTemplateText = `
 To select an action, use the "move" or "switch" command along with the ID of your choice.
 *Current Pokémon*: {{ .CurrPokemonName }}
+{{ if ne .Weather "clear" -}}
+*Weather*: {{ .Weather }} ({{ .WeatherTurns }} turns left)
+{{ else -}}
+{{- end -}}
 {{ printf "\u0060\u0060\u0060" -}}
 MOVES
-{{ range $id, $moveName := .MoveSlots }}  {{ toBaseOne $id }}: {{ $moveName }}
+{{ range $id, $move := .MoveSlots }}  {{ toBaseOne $id }}: {{ $move.Name }}{{ if $move.MaxPP }} ({{ $move.PP }}/{{ $move.MaxPP }} PP){{ end }}
 {{ end -}}
 PARTY
 {{ range $id, $pkmnName := .PartySlots }}  {{ toBaseOne $id }}: {{ $pkmnName }}
@@ -252,7 +382,25 @@ var actionOptionsTemplate *template.Template
 // Move report template. Contains a full textual representation of a move
 // report, telling trainers what happened when a move was used.
 var moveReportTemplateText = `
+{{ if .Recharging -}}
+{{ .UserActionPrefix }} {{ .UserPokemonName }} must recharge!
+{{ else -}}
 {{ .UserActionPrefix }} {{ .UserPokemonName }} used {{ .MoveName }}!
+{{ end -}}
+{{ if .Charging -}}
+{{ .UserActionPrefix }} {{ .UserPokemonName }} is charging up!
+{{ else -}}
+{{- end -}}
+{{ if .Protected -}}
+{{ .UserActionPrefix }} {{ .UserPokemonName }} protected itself!
+{{ else if .ProtectFailed -}}
+But it failed!
+{{ else -}}
+{{- end -}}
+{{ if .TargetProtected -}}
+{{ .TargetActionPrefix }} {{ .TargetPokemonName }} protected itself!
+{{ else -}}
+{{- end -}}
 {{ if .Missed -}}
 But the attack missed!
 {{ else if .CriticalHit -}}
@@ -339,12 +487,151 @@ The move had no effect...
 {{ .TargetActionPrefix }} {{ .TargetPokemonName }} has been burned!
 {{ else -}}
 {{- end -}}
+{{ if .UserFatigued -}}
+{{ .UserActionPrefix }} {{ .UserPokemonName }} became confused due to fatigue!
+{{ else -}}
+{{- end -}}
 {{- if not .TargetsUser }}
 {{ printf "\u0060" }}{{ printf "%-15s" .TargetPokemonName }}: {{ .TargetHPBar }}{{ printf "\u0060" }}
 {{- end -}}
 `
 var moveReportTemplate *template.Template
 
+// Ability report template. Tells trainers what happened when a Pokemon's
+// ability activated.
+var abilityReportTemplateText = `
+{{ .HolderActionPrefix }} {{ .HolderPokemonName }}'s {{ .Ability }} activated!
+{{ if lt .OpponentAttStageChange 0 -}}
+{{ .OpponentActionPrefix }} {{ .OpponentPokemonName }}'s attack has decreased!
+{{ else -}}
+{{- end -}}
+{{ if .OpponentParalyzed -}}
+{{ .OpponentActionPrefix }} {{ .OpponentPokemonName }} has been paralyzed!
+{{ else -}}
+{{- end -}}
+{{ if gt .HolderSpeedStageChange 0 -}}
+{{ .HolderActionPrefix }} {{ .HolderPokemonName }}'s speed has increased!
+{{ else -}}
+{{- end -}}
+{{ if .Endured -}}
+{{ .HolderActionPrefix }} {{ .HolderPokemonName }} endured the hit!
+{{ else -}}
+{{- end -}}
+{{ if .Immune -}}
+It doesn't affect {{ .HolderActionPrefix }} {{ .HolderPokemonName }}...
+{{ else -}}
+{{- end -}}
+{{ if .PreventedAilment -}}
+{{ .HolderActionPrefix }} {{ .HolderPokemonName }} is unaffected!
+{{ else -}}
+{{- end -}}
+`
+var abilityReportTemplate *template.Template
+
+// Held item report template. Tells trainers what happened when a Pokemon's
+// held item activated.
+var heldItemReportTemplateText = `
+{{ .HolderActionPrefix }} {{ .HolderPokemonName }} restored {{ .Healing }} HP using its {{ .ItemName }}!
+`
+var heldItemReportTemplate *template.Template
+
+// Weather report template. Tells trainers when the weather starts, continues
+// or clears up.
+var weatherReportTemplateText = `
+{{ if eq .Weather "rain" -}}
+{{ if .Started }}It started to rain!{{ else if .Ended }}The rain stopped.{{ else }}Rain continues to fall.{{ end }}
+{{ else if eq .Weather "harsh sunlight" -}}
+{{ if .Started }}The sunlight turned harsh!{{ else if .Ended }}The harsh sunlight faded.{{ else }}The sunlight is strong.{{ end }}
+{{ else if eq .Weather "sandstorm" -}}
+{{ if .Started }}A sandstorm kicked up!{{ else if .Ended }}The sandstorm subsided.{{ else }}The sandstorm rages.{{ end }}
+{{ else if eq .Weather "hail" -}}
+{{ if .Started }}It started to hail!{{ else if .Ended }}The hail stopped.{{ else }}Hail continues to fall.{{ end }}
+{{ else -}}
+{{- end -}}
+`
+var weatherReportTemplate *template.Template
+
+// Weather damage template. Tells trainers when a Pokemon was hurt by the
+// weather at the end of a turn.
+var weatherDamageTemplateText = `
+{{ .ActionPrefix }} {{ .PokemonName }} is buffeted by the {{ .Weather }}!
+{{ .ActionPrefix }} {{ .PokemonName }} took {{ .Damage }} damage!
+{{ if .Fainted -}}
+{{ .ActionPrefix }} {{ .PokemonName }} has fainted!
+{{ else -}}
+{{- end -}}
+{{ printf "\u0060" }}{{ printf "%-15s" .PokemonName }}: {{ .HPBar }}{{ printf "\u0060" }}
+`
+var weatherDamageTemplate *template.Template
+
+// Side condition report template. Tells trainers when a condition is created
+// on one side of the field or runs out.
+var sideConditionReportTemplateText = `
+{{ if not .Started -}}
+The {{ .Condition }} on {{ .SideOwner }} side wore off.
+{{ else if eq .Condition "stealth rock" -}}
+Pointed stones float in the air around {{ .SideOwner }} team!
+{{ else if eq .Condition "spikes" -}}
+Spikes were scattered all around the feet of {{ .SideOwner }} team!
+{{ else if eq .Condition "toxic spikes" -}}
+Poison spikes were scattered all around the feet of {{ .SideOwner }} team!
+{{ else if eq .Condition "reflect" -}}
+Reflect made {{ .SideOwner }} team stronger against physical moves!
+{{ else if eq .Condition "light screen" -}}
+Light Screen made {{ .SideOwner }} team stronger against special moves!
+{{ else if eq .Condition "tailwind" -}}
+The tailwind blew from behind {{ .SideOwner }} team!
+{{ else -}}
+{{- end -}}
+`
+var sideConditionReportTemplate *template.Template
+
+// Hazard report template. Tells trainers what happened when a Pokemon was
+// sent out onto a side of the field with entry hazards on it.
+var hazardReportTemplateText = `
+{{ if .StealthRockDamage -}}
+Pointed stones dug into {{ .ActionPrefix }} {{ .PokemonName }} for {{ .StealthRockDamage }} damage!
+{{ else -}}
+{{- end -}}
+{{ if .SpikesDamage -}}
+{{ .ActionPrefix }} {{ .PokemonName }} was hurt by the spikes for {{ .SpikesDamage }} damage!
+{{ else -}}
+{{- end -}}
+{{ if .Poisoned -}}
+{{ .ActionPrefix }} {{ .PokemonName }} has been poisoned!
+{{ else -}}
+{{- end -}}
+{{ if .ToxicSpikesAbsorbed -}}
+{{ .ActionPrefix }} {{ .PokemonName }} absorbed the poison spikes!
+{{ else -}}
+{{- end -}}
+{{ if .Fainted -}}
+{{ .ActionPrefix }} {{ .PokemonName }} has fainted!
+{{ else -}}
+{{- end -}}
+{{ printf "\u0060" }}{{ printf "%-15s" .PokemonName }}: {{ .HPBar }}{{ printf "\u0060" }}
+`
+var hazardReportTemplate *template.Template
+
+// Item used template. Tells trainers what happened when an item from a bag was
+// used in battle.
+var itemUsedTemplateText = `
+{{ .TrainerName }} used a {{ .ItemName }} on {{ .PokemonName }}!
+{{ if not .Activated -}}
+But it had no effect...
+{{ else -}}
+{{- end -}}
+{{ if .Healing -}}
+{{ .PokemonName }} recovered {{ .Healing }} HP!
+{{ else -}}
+{{- end -}}
+{{ if .Cured -}}
+{{ .PokemonName }} was cured of its ailment!
+{{ else -}}
+{{- end -}}
+`
+var itemUsedTemplate *template.Template
+
 var switchPokemonTemplateText = `
 {{ .Switcher }} has withdrawn {{ .WithdrawnPokemon }}.
 {{ .Switcher }} sent out {{ .SelectedPokemon }}! (Lv. {{ .SelectedLevel }})
@@ -361,30 +648,96 @@ A fainted Pokémon cannot use a move. You must switch to a battle-ready Pokémon
 `
 var faintedPokemonUsingMoveTemplate *template.Template
 
+// Must replace template. Tells a trainer that their active Pokemon fainted and
+// has to be replaced before they can do anything else.
+var mustReplaceTemplateText = `
+{{ . }} can no longer fight! Use "switch" with the ID of a battle-ready Pokémon to send it out. This won't use up your turn.
+`
+var mustReplaceTemplate *template.Template
+
 var trainerLostTemplateText = `
 {{ .LostTrainer }} is out of usable Pokémon. {{ .WonTrainer }} has won the battle!
 `
 var trainerLostTemplate *template.Template
 
 var wildBattleStartedTemplateText = `
-A wild {{ .WildPokemonName }} appeared! (Lv. {{ .Level }})
+A wild {{ if .Shiny }}shiny {{ end }}{{ .WildPokemonName }}{{ if .Form }} ({{ .Form }} form){{ end }} appeared! (Lv. {{ .Level }})
 `
 var wildBattleStartedTemplate *template.Template
 
+// Gym battle started template. Announces the gym leader the trainer is
+// fighting and their first Pokemon.
+var gymBattleStartedTemplateText = `
+Gym leader {{ .LeaderName }} wants to battle! {{ .LeaderName }} sent out {{ .PokemonName }}! (Lv. {{ .Level }})
+`
+var gymBattleStartedTemplate *template.Template
+
+var noSuchRegionTemplateText = `
+There's no region called "{{ . }}". Try kanto, johto, hoenn, sinnoh, unova or kalos.
+`
+var noSuchRegionTemplate *template.Template
+
+var allGymsBeatenTemplateText = `
+You've already beaten every gym leader in {{ . }}!
+`
+var allGymsBeatenTemplate *template.Template
+
+var gymWhenInWrongModeTemplateText = `
+You cannot challenge a gym leader right now!
+`
+var gymWhenInWrongModeTemplate *template.Template
+
+// Badge earned template. Congratulates a trainer on beating a gym leader.
+var badgeEarnedTemplateText = `
+{{ .TrainerName }} defeated gym leader {{ .LeaderName }} and earned the {{ .Badge }}! ({{ .Badges }}/{{ .TotalBadges }} {{ .Region }} badges)
+{{- range .NewAreas }}
+{{ $.TrainerName }} can now travel to {{ . }} in {{ $.Region }}!
+{{- end }}
+`
+var badgeEarnedTemplate *template.Template
+
 var cannotCatchTrainerPokemonTemplateText = `
 The trainer blocked the ball! Don't be a thief!
 `
 var cannotCatchTrainerPokemonTemplate *template.Template
 
 var pokemonCaughtTemplateText = `
-You threw a Pokeball at the wild {{ . }}...
-The wild {{ . }} was caught!
+You threw a {{ .BallName }} at the wild {{ .PokemonName }}...
+{{ if .Critical -}}
+A critical capture!
+{{ else -}}
+{{- end -}}
+The ball shook {{ .Shakes }} {{ if eq .Shakes 1 }}time{{ else }}times{{ end }}...
+Gotcha! The wild {{ .PokemonName }} was caught!
+{{ if .Shiny -}}
+It's a shiny Pokémon{{ if .Form }} in its {{ .Form }} form{{ end }}!
+{{ else if .Form -}}
+It's in its {{ .Form }} form.
+{{ else -}}
+{{- end -}}
+{{ if .BoxID -}}
+Your party is full, so {{ .PokemonName }} was sent to box {{ .BoxID }} of your PC.
+{{ else -}}
+{{- end -}}
 `
 var pokemonCaughtTemplate *template.Template
 
 var pokemonNotCaughtTemplateText = `
-You threw a Pokeball at the wild {{ . }}...
-But it broke out!
+You threw a {{ .BallName }} at the wild {{ .PokemonName }}...
+{{ if .Critical -}}
+A critical capture!
+{{ else -}}
+{{- end -}}
+The ball shook {{ .Shakes }} {{ if eq .Shakes 1 }}time{{ else }}times{{ end }}...
+{{ if eq .Shakes 0 -}}
+Oh no! The Pokémon broke free!
+{{ else if eq .Shakes 1 -}}
+Aww! It appeared to be caught!
+{{ else if eq .Shakes 2 -}}
+Aargh! Almost had it!
+{{ else -}}
+Gah! It was so close, too!
+{{ end -}}
 `
 var pokemonNotCaughtTemplate *template.Template
 
@@ -448,8 +801,20 @@ The Pokémon is already in battle!
 `
 var switchToCurrentPokemonTemplate *template.Template
 
+var experienceGainedTemplateText = `
+{{ range .Gains -}}
+{{ .PokemonName }} gained {{ .Experience }} experience points for defeating {{ $.FaintedName }}!
+{{ end -}}
+`
+var experienceGainedTemplate *template.Template
+
 var levelUpTemplateText = `
 {{ .Name }} grew to level {{ .Level }}!
+{{ printf "\u0060\u0060\u0060" -}}
+  HP   : {{ printf "%+3d" .StatChanges.HP        }}    Att  : {{ printf "%+3d" .StatChanges.Attack }}
+  Def  : {{ printf "%+3d" .StatChanges.Defense   }}    SpAtt: {{ printf "%+3d" .StatChanges.SpAttack }}
+  SpDef: {{ printf "%+3d" .StatChanges.SpDefense }}    Speed: {{ printf "%+3d" .StatChanges.Speed }}
+{{ printf "\u0060\u0060\u0060" }}
 `
 var levelUpTemplate *template.Template
 
@@ -468,6 +833,327 @@ MOVES
 `
 var forgetMoveTemplate *template.Template
 
+// Evolving template. Tells the trainer that one of their Pokemon is about to
+// evolve and how to stop it.
+var evolvingTemplateText = `
+What? {{ .PokemonName }} is evolving! Use "{{ .SlashCommand }} evolve" to let it evolve or "{{ .SlashCommand }} cancel" to stop it.
+`
+var evolvingTemplate *template.Template
+
+var evolvedTemplateText = `
+Congratulations! Your {{ .OldName }} evolved into {{ .NewName }}!
+`
+var evolvedTemplate *template.Template
+
+var itemHadNoEffectTemplateText = `
+The {{ .ItemName }} won't have any effect on {{ .PokemonName }}.
+`
+var itemHadNoEffectTemplate *template.Template
+
+var notUsableOutsideBattleTemplateText = `
+A {{ . }} can't be used outside of battle.
+`
+var notUsableOutsideBattleTemplate *template.Template
+
+var evolutionItemInBattleTemplateText = `
+A {{ . }} can't be used in battle. Use it on a Pokémon when you're not battling instead.
+`
+var evolutionItemInBattleTemplate *template.Template
+
+var walkedTemplateText = `
+You took your party for a walk. Your Pokémon seem to enjoy your company!
+`
+var walkedTemplate *template.Template
+
+var tooSoonToWalkTemplateText = `
+Your Pokémon are still resting from the last walk. Try again in {{ . }} minute{{ if ne . 1 }}s{{ end }}.
+`
+var tooSoonToWalkTemplate *template.Template
+
+var healedTemplateText = `
+Your Pokémon are fighting fit! Their HP and PP were restored and their status conditions were cured.
+`
+var healedTemplate *template.Template
+
+var tooSoonToHealTemplateText = `
+The Pokémon Center is still busy with your last visit. Try again in {{ . }} minute{{ if ne . 1 }}s{{ end }}.
+`
+var tooSoonToHealTemplate *template.Template
+
+var allFaintedTemplateText = `
+All of your Pokémon have fainted! Use "{{ . }} heal" to get them back on their feet.
+`
+var allFaintedTemplate *template.Template
+
+var noPPLeftTemplateText = `
+{{ .PokemonName }} has no PP left for {{ .MoveName }}!
+`
+var noPPLeftTemplate *template.Template
+
+var viewDexTemplateText = `
+{{ printf "\u0060\u0060\u0060" -}}
+POKÉDEX          SEEN   CAUGHT
+{{ range .Regions }}  {{ printf "%-14s" .Name }} {{ printf "%3d" .Seen }}    {{ printf "%3d" .Caught }}/{{ .Total }}
+{{ end }}  {{ printf "%-14s" "National" }} {{ printf "%3d" .Seen }}    {{ printf "%3d" .Caught }}/{{ .Total }}
+{{ printf "\u0060\u0060\u0060" }}
+Use "{{ .SlashCommand }} dex" followed by a Pokémon's name or number to see its entry.
+`
+var viewDexTemplate *template.Template
+
+var dexEntryTemplateText = `
+	*{{ .Name }}* (No. {{ printf "%03d" .ID }}){{ if .Caught }} - caught{{ end }}
+{{ if .FlavorText }}_{{ .FlavorText }}_
+{{ end -}}
+{{ printf "\u0060\u0060\u0060" -}}
+BASE STATS
+  Types : {{ .Type1 }} {{ .Type2 }}
+  HP    : {{ printf "%3d" .HP        }}    Att   : {{ printf "%3d" .Attack }}
+  Def   : {{ printf "%3d" .Defense   }}    SpAtt : {{ printf "%3d" .SpAttack }}
+  SpDef : {{ printf "%3d" .SpDefense }}    Speed : {{ printf "%3d" .Speed }}
+{{ printf "\u0060\u0060\u0060" }}
+`
+var dexEntryTemplate *template.Template
+
+var noSuchSpeciesTemplateText = `
+There's no Pokémon called "{{ . }}" in the Pokédex.
+`
+var noSuchSpeciesTemplate *template.Template
+
+var speciesNotSeenTemplateText = `
+You haven't seen Pokémon No. {{ printf "%03d" . }} yet!
+`
+var speciesNotSeenTemplate *template.Template
+
+var viewAreasTemplateText = `
+You're currently in {{ .Area }} in {{ .Region }}.
+{{ printf "\u0060\u0060\u0060" -}}
+{{ range .Regions }}{{ .Name }}
+{{ range .Areas }}  {{ if .Unlocked }}{{ .Name }}{{ else }}{{ printf "%-18s" .Name }} ({{ .Badges }} badges){{ end }}
+{{ end }}{{ end -}}
+{{ printf "\u0060\u0060\u0060" }}
+Use "{{ .SlashCommand }} travel" followed by a region and area to go somewhere else.
+`
+var viewAreasTemplate *template.Template
+
+var noSuchAreaTemplateText = `
+There's no area called "{{ .Area }}" in {{ .Region }}. Use "{{ .SlashCommand }} travel" to see where you can go.
+`
+var noSuchAreaTemplate *template.Template
+
+var areaLockedTemplateText = `
+You need {{ .Badges }} {{ .Region }} badges to travel to {{ .Area }}.
+`
+var areaLockedTemplate *template.Template
+
+var traveledTemplateText = `
+You traveled to {{ .Area }} in {{ .Region }}. Wild Pokémon here are waiting for you!
+`
+var traveledTemplate *template.Template
+
+// Prize money template. Tells a trainer how much they earned for winning a
+// battle.
+var prizeMoneyTemplateText = `
+{{ .TrainerName }} got ₽{{ .Prize }} for winning! (₽{{ .Balance }} total)
+`
+var prizeMoneyTemplate *template.Template
+
+var prizeMoneyPaidTemplateText = `
+{{ .TrainerName }} paid ₽{{ .Prize }} to {{ .WinnerName }} for losing. (₽{{ .Balance }} left)
+`
+var prizeMoneyPaidTemplate *template.Template
+
+// View mart template. Lists the items sold at the Poke Mart and their prices.
+var viewMartTemplateText = `
+{{ printf "\u0060\u0060\u0060" -}}
+POKé MART (you have ₽{{ .Money }})
+{{ range .Items }}  {{ printf "%-15s" .Name }} ₽{{ .Price }}{{ if .Locked }} (needs {{ .Badges }} badges){{ end }}
+{{ else }}  (nothing for sale)
+{{ end -}}
+{{ printf "\u0060\u0060\u0060" }}
+Use "{{ .SlashCommand }} mart buy _item_ [_count_]" to buy something.
+`
+var viewMartTemplate *template.Template
+
+var notSoldAtMartTemplateText = `
+The Poké Mart doesn't sell anything called '{{ . }}'.
+`
+var notSoldAtMartTemplate *template.Template
+
+var martItemLockedTemplateText = `
+The Poké Mart will only sell you the {{ .ItemName }} once you have {{ .Badges }} badges.
+`
+var martItemLockedTemplate *template.Template
+
+var invalidPurchaseCountTemplateText = `
+You can buy between 1 and {{ . }} of an item at a time.
+`
+var invalidPurchaseCountTemplate *template.Template
+
+var notEnoughMoneyTemplateText = `
+{{ .Count }} {{ .ItemName }} costs ₽{{ .Cost }}, but you only have ₽{{ .Money }}.
+`
+var notEnoughMoneyTemplate *template.Template
+
+var itemsBoughtTemplateText = `
+You bought {{ .Count }} {{ .ItemName }} for ₽{{ .Cost }}. You have ₽{{ .Money }} left.
+`
+var itemsBoughtTemplate *template.Template
+
+var viewBoxesTemplateText = `
+{{ printf "\u0060\u0060\u0060" -}}
+PC
+{{ range $id, $size := .Sizes }}  Box {{ toBaseOne $id }}: {{ printf "%2d" $size }}/{{ $.BoxSize }}
+{{ end -}}
+{{ printf "\u0060\u0060\u0060" }}
+Use "{{ .SlashCommand }} box" followed by a box number to see what's inside.
+`
+var viewBoxesTemplate *template.Template
+
+var viewBoxTemplateText = `
+{{ printf "\u0060\u0060\u0060" -}}
+BOX {{ .BoxID }} (page {{ .Page }}/{{ .PageCount }})
+{{ range .Entries }}  {{ printf "%2d" .Position }}: {{ printf "%-12s" .Name }} Lv. {{ .Level }}
+{{ else }}  (empty)
+{{ end -}}
+{{ printf "\u0060\u0060\u0060" }}
+{{- if .NextPage }}
+Use "{{ .SlashCommand }} box {{ .BoxID }} {{ .NextPage }}" to see the next page.
+{{- end }}
+`
+var viewBoxTemplate *template.Template
+
+var invalidBoxTemplateText = `
+There is no box {{ . }} in your PC!
+`
+var invalidBoxTemplate *template.Template
+
+var invalidBoxPositionTemplateText = `
+There is no Pokémon at position {{ .Position }} of box {{ .BoxID }}!
+`
+var invalidBoxPositionTemplate *template.Template
+
+var lastPokemonTemplateText = `
+You can't part with your last Pokémon!
+`
+var lastPokemonTemplate *template.Template
+
+var pcFullTemplateText = `
+There's no room left in your PC!
+`
+var pcFullTemplate *template.Template
+
+var partyFullTemplateText = `
+Your party is full! Deposit a Pokémon first.
+`
+var partyFullTemplate *template.Template
+
+var depositedTemplateText = `
+{{ .PokemonName }} was put in box {{ .BoxID }} of your PC.
+`
+var depositedTemplate *template.Template
+
+var withdrawnTemplateText = `
+{{ . }} was taken out of your PC and joined your party.
+`
+var withdrawnTemplate *template.Template
+
+var partySwappedTemplateText = `
+{{ .FirstName }} is now in slot {{ .FirstSlot }} and {{ .SecondName }} is now in slot {{ .SecondSlot }}.
+`
+var partySwappedTemplate *template.Template
+
+var nicknamedTemplateText = `
+{{- if .Nicknamed -}}
+{{ .OldName }} will now be known as {{ .NewName }}!
+{{- else -}}
+{{ .OldName }} will now be known by its species name, {{ .SpeciesName }}.
+{{- end }}
+`
+var nicknamedTemplate *template.Template
+
+var nicknameTooLongTemplateText = `
+Nicknames can't be longer than {{ . }} characters!
+`
+var nicknameTooLongTemplate *template.Template
+
+var confirmReleaseTemplateText = `
+Are you sure you want to release {{ .PokemonName }}? You won't be able to get it back!
+Use "{{ .SlashCommand }} yes" to release it or "{{ .SlashCommand }} no" to keep it.
+`
+var confirmReleaseTemplate *template.Template
+
+var releasedTemplateText = `
+{{ .PokemonName }} was released. Bye-bye, {{ .PokemonName }}!
+{{- if .ItemName }}
+You got back the {{ .ItemName }} it was holding.
+{{- end }}
+`
+var releasedTemplate *template.Template
+
+var releaseCancelledTemplateText = `
+You decided to keep {{ . }}.
+`
+var releaseCancelledTemplate *template.Template
+
+var noTradingSelfTemplateText = `
+You can't trade with yourself!
+`
+var noTradingSelfTemplate *template.Template
+
+var notTradingTemplateText = `
+You aren't trading with anyone right now.
+`
+var notTradingTemplate *template.Template
+
+var tradeOfferedTemplateText = `
+Trainer {{ .RequesterName }} wants to trade their {{ .OfferedName }} (Lv. {{ .OfferedLevel }}) with {{ .PartnerName }}!
+
+{{ .PartnerName }}, use "{{ .SlashCommand }} trade {{ .RequesterName }} _slot_" to offer a Pokémon in return.
+`
+var tradeOfferedTemplate *template.Template
+
+var tradeAgreedTemplateText = `
+{{ .RequesterName }} is offering {{ .OfferedName }} (Lv. {{ .OfferedLevel }}) for {{ .PartnerName }}'s {{ .ReturnedName }} (Lv. {{ .ReturnedLevel }})!
+
+Both trainers need to use "{{ .SlashCommand }} trade confirm" to go through with the trade, or "{{ .SlashCommand }} trade cancel" to back out.
+`
+var tradeAgreedTemplate *template.Template
+
+var tradeNotAgreedTemplateText = `
+{{ . }} hasn't offered you a Pokémon yet!
+`
+var tradeNotAgreedTemplate *template.Template
+
+var tradeConfirmedTemplateText = `
+{{ .RequesterName }} confirmed the trade! Waiting on {{ .PartnerName }}...
+`
+var tradeConfirmedTemplate *template.Template
+
+var tradeCompleteTemplateText = `
+{{ .Trainer1Name }} sent {{ .Pokemon1Name }} to {{ .Trainer2Name }}, and {{ .Trainer2Name }} sent {{ .Pokemon2Name }} to {{ .Trainer1Name }}. Take good care of them!
+`
+var tradeCompleteTemplate *template.Template
+
+var tradeFailedTemplateText = `
+One of the Pokémon in the trade isn't in its trainer's party anymore, so the trade was cancelled.
+`
+var tradeFailedTemplate *template.Template
+
+var traderBusyTemplateText = `
+{{ . }} is busy right now, so there can't be a trade. Try again once they're done.
+`
+var traderBusyTemplate *template.Template
+
+var tradeCancelledTemplateText = `
+The trade between {{ .RequesterName }} and {{ .PartnerName }} was cancelled.
+`
+var tradeCancelledTemplate *template.Template
+
+var evolutionCancelledTemplateText = `
+Huh? {{ . }} stopped evolving!
+`
+var evolutionCancelledTemplate *template.Template
+
 var giveUpLearningMoveTemplateText = `
 {{ .PokemonName }} gave up on learning {{ .MoveName }}.
 `
@@ -478,6 +1164,81 @@ var replacedMoveTemplateText = `
 `
 var replacedMoveTemplate *template.Template
 
+// View bag template. This template will be shown when a trainer wants to see
+// the items in their bag.
+var viewBagTemplateText = `
+{{ printf "\u0060\u0060\u0060" -}}
+BAG (₽{{ .Money }})
+{{ range .Bag }}  {{ printf "%-15s" .Item.Name }} x{{ .Count }}
+{{ else }}  (empty)
+{{ end -}}
+{{ printf "\u0060\u0060\u0060" }}
+`
+var viewBagTemplate *template.Template
+
+var noSuchItemTemplateText = `
+There's no item called '{{ . }}'.
+`
+var noSuchItemTemplate *template.Template
+
+var noneOfItemTemplateText = `
+You don't have any {{ . }}!
+`
+var noneOfItemTemplate *template.Template
+
+var ballOutsideCatchTemplateText = `
+Balls are thrown with the catch command, like "catch {{ . }}".
+`
+var ballOutsideCatchTemplate *template.Template
+
+var notABallTemplateText = `
+A {{ . }} isn't something you can catch Pokémon with!
+`
+var notABallTemplate *template.Template
+
+var heldItemOnlyTemplateText = `
+A {{ . }} can't be used directly. Give it to a Pokémon to hold instead.
+`
+var heldItemOnlyTemplate *template.Template
+
+var choiceLockedTemplateText = `
+{{ .PokemonName }} can only use {{ .MoveName }} because of its choice-band!
+`
+var choiceLockedTemplate *template.Template
+
+// Move forced template. Shown when a trainer tries to pick an action while
+// their Pokemon has to keep using a move.
+var moveForcedTemplateText = `
+{{ .PokemonName }} is busy with {{ .MoveName }} and can't do anything else this turn!
+`
+var moveForcedTemplate *template.Template
+
+// Move auto-filled template. Tells a trainer that their Pokemon's next action
+// was picked for them because it has to keep using a move.
+var moveAutoFilledTemplateText = `
+{{ .PokemonName }} has to keep going with {{ .MoveName }}, so it was picked as your next action.
+`
+var moveAutoFilledTemplate *template.Template
+
+var itemGivenTemplateText = `
+{{ .PokemonName }} is now holding the {{ .ItemName }}.
+{{ if .OldItemName -}}
+The {{ .OldItemName }} it was holding was put back in the bag.
+{{ else -}}
+{{- end -}}
+`
+var itemGivenTemplate *template.Template
+
+var itemTakenTemplateText = `
+You took the {{ .ItemName }} from {{ .PokemonName }} and put it in the bag.
+`
+var itemTakenTemplate *template.Template
+
+var notHoldingItemTemplateText = `
+{{ . }} isn't holding anything.
+`
+var notHoldingItemTemplate *template.Template
+
 // toBaseOne converts the given number from base-zero to base-one by adding one
 // to it. This is intended to be used in templates.
 func toBaseOne(i int) int {
@@ -510,9 +1271,11 @@ func init() {
 	switchConfirmationTemplate = template.Must(template.New("").Funcs(funcMap).Parse(switchConfirmationTemplateText))
 	actionOptionsTemplate = template.Must(template.New("").Funcs(funcMap).Parse(actionOptionsTemplateText))
 	moveReportTemplate = template.Must(template.New("").Funcs(funcMap).Parse(moveReportTemplateText))
+	abilityReportTemplate = template.Must(template.New("").Funcs(funcMap).Parse(abilityReportTemplateText))
 	switchPokemonTemplate = template.Must(template.New("").Funcs(funcMap).Parse(switchPokemonTemplateText))
 	initialPokemonSendOutTemplate = template.Must(template.New("").Funcs(funcMap).Parse(initialPokemonSendOutTemplateText))
 	faintedPokemonUsingMoveTemplate = template.Must(template.New("").Funcs(funcMap).Parse(faintedPokemonUsingMoveTemplateText))
+	mustReplaceTemplate = template.Must(template.New("").Funcs(funcMap).Parse(mustReplaceTemplateText))
 	trainerLostTemplate = template.Must(template.New("").Funcs(funcMap).Parse(trainerLostTemplateText))
 	wildBattleStartedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(wildBattleStartedTemplateText))
 	cannotCatchTrainerPokemonTemplate = template.Must(template.New("").Funcs(funcMap).Parse(cannotCatchTrainerPokemonTemplateText))
@@ -530,10 +1293,90 @@ func init() {
 	invalidPartySlotTemplate = template.Must(template.New("").Funcs(funcMap).Parse(invalidPartySlotTemplateText))
 	switchToFaintedPokemonTemplate = template.Must(template.New("").Funcs(funcMap).Parse(switchToFaintedPokemonTemplateText))
 	switchToCurrentPokemonTemplate = template.Must(template.New("").Funcs(funcMap).Parse(switchToCurrentPokemonTemplateText))
+	experienceGainedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(experienceGainedTemplateText))
 	levelUpTemplate = template.Must(template.New("").Funcs(funcMap).Parse(levelUpTemplateText))
 	learnedMoveTemplate = template.Must(template.New("").Funcs(funcMap).Parse(learnedMoveTemplateText))
 	forgetMoveTemplate = template.Must(template.New("").Funcs(funcMap).Parse(forgetMoveTemplateText))
 	forgetMoveHelpTemplate = template.Must(template.New("").Funcs(funcMap).Parse(forgetMoveHelpTemplateText))
 	giveUpLearningMoveTemplate = template.Must(template.New("").Funcs(funcMap).Parse(giveUpLearningMoveTemplateText))
 	replacedMoveTemplate = template.Must(template.New("").Funcs(funcMap).Parse(replacedMoveTemplateText))
+	itemConfirmationTemplate = template.Must(template.New("").Funcs(funcMap).Parse(itemConfirmationTemplateText))
+	heldItemReportTemplate = template.Must(template.New("").Funcs(funcMap).Parse(heldItemReportTemplateText))
+	weatherReportTemplate = template.Must(template.New("").Funcs(funcMap).Parse(weatherReportTemplateText))
+	weatherDamageTemplate = template.Must(template.New("").Funcs(funcMap).Parse(weatherDamageTemplateText))
+	sideConditionReportTemplate = template.Must(template.New("").Funcs(funcMap).Parse(sideConditionReportTemplateText))
+	hazardReportTemplate = template.Must(template.New("").Funcs(funcMap).Parse(hazardReportTemplateText))
+	itemUsedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(itemUsedTemplateText))
+	viewBagTemplate = template.Must(template.New("").Funcs(funcMap).Parse(viewBagTemplateText))
+	noSuchItemTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noSuchItemTemplateText))
+	noneOfItemTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noneOfItemTemplateText))
+	ballOutsideCatchTemplate = template.Must(template.New("").Funcs(funcMap).Parse(ballOutsideCatchTemplateText))
+	notABallTemplate = template.Must(template.New("").Funcs(funcMap).Parse(notABallTemplateText))
+	heldItemOnlyTemplate = template.Must(template.New("").Funcs(funcMap).Parse(heldItemOnlyTemplateText))
+	choiceLockedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(choiceLockedTemplateText))
+	moveForcedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(moveForcedTemplateText))
+	moveAutoFilledTemplate = template.Must(template.New("").Funcs(funcMap).Parse(moveAutoFilledTemplateText))
+	itemGivenTemplate = template.Must(template.New("").Funcs(funcMap).Parse(itemGivenTemplateText))
+	itemTakenTemplate = template.Must(template.New("").Funcs(funcMap).Parse(itemTakenTemplateText))
+	notHoldingItemTemplate = template.Must(template.New("").Funcs(funcMap).Parse(notHoldingItemTemplateText))
+	gymBattleStartedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(gymBattleStartedTemplateText))
+	noSuchRegionTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noSuchRegionTemplateText))
+	allGymsBeatenTemplate = template.Must(template.New("").Funcs(funcMap).Parse(allGymsBeatenTemplateText))
+	gymWhenInWrongModeTemplate = template.Must(template.New("").Funcs(funcMap).Parse(gymWhenInWrongModeTemplateText))
+	badgeEarnedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(badgeEarnedTemplateText))
+	evolvingHelpTemplate = template.Must(template.New("").Funcs(funcMap).Parse(evolvingHelpTemplateText))
+	evolvingTemplate = template.Must(template.New("").Funcs(funcMap).Parse(evolvingTemplateText))
+	evolvedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(evolvedTemplateText))
+	viewBoxesTemplate = template.Must(template.New("").Funcs(funcMap).Parse(viewBoxesTemplateText))
+	viewBoxTemplate = template.Must(template.New("").Funcs(funcMap).Parse(viewBoxTemplateText))
+	invalidBoxTemplate = template.Must(template.New("").Funcs(funcMap).Parse(invalidBoxTemplateText))
+	invalidBoxPositionTemplate = template.Must(template.New("").Funcs(funcMap).Parse(invalidBoxPositionTemplateText))
+	lastPokemonTemplate = template.Must(template.New("").Funcs(funcMap).Parse(lastPokemonTemplateText))
+	pcFullTemplate = template.Must(template.New("").Funcs(funcMap).Parse(pcFullTemplateText))
+	partyFullTemplate = template.Must(template.New("").Funcs(funcMap).Parse(partyFullTemplateText))
+	depositedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(depositedTemplateText))
+	withdrawnTemplate = template.Must(template.New("").Funcs(funcMap).Parse(withdrawnTemplateText))
+	partySwappedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(partySwappedTemplateText))
+	nicknamedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(nicknamedTemplateText))
+	nicknameTooLongTemplate = template.Must(template.New("").Funcs(funcMap).Parse(nicknameTooLongTemplateText))
+	confirmReleaseTemplate = template.Must(template.New("").Funcs(funcMap).Parse(confirmReleaseTemplateText))
+	releasedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(releasedTemplateText))
+	releaseCancelledTemplate = template.Must(template.New("").Funcs(funcMap).Parse(releaseCancelledTemplateText))
+	releasingHelpTemplate = template.Must(template.New("").Funcs(funcMap).Parse(releasingHelpTemplateText))
+	noTradingSelfTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noTradingSelfTemplateText))
+	notTradingTemplate = template.Must(template.New("").Funcs(funcMap).Parse(notTradingTemplateText))
+	tradeOfferedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeOfferedTemplateText))
+	tradeAgreedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeAgreedTemplateText))
+	tradeNotAgreedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeNotAgreedTemplateText))
+	tradeConfirmedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeConfirmedTemplateText))
+	tradeCompleteTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeCompleteTemplateText))
+	tradeFailedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeFailedTemplateText))
+	traderBusyTemplate = template.Must(template.New("").Funcs(funcMap).Parse(traderBusyTemplateText))
+	tradeCancelledTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tradeCancelledTemplateText))
+	itemHadNoEffectTemplate = template.Must(template.New("").Funcs(funcMap).Parse(itemHadNoEffectTemplateText))
+	notUsableOutsideBattleTemplate = template.Must(template.New("").Funcs(funcMap).Parse(notUsableOutsideBattleTemplateText))
+	evolutionItemInBattleTemplate = template.Must(template.New("").Funcs(funcMap).Parse(evolutionItemInBattleTemplateText))
+	walkedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(walkedTemplateText))
+	tooSoonToWalkTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tooSoonToWalkTemplateText))
+	healedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(healedTemplateText))
+	tooSoonToHealTemplate = template.Must(template.New("").Funcs(funcMap).Parse(tooSoonToHealTemplateText))
+	allFaintedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(allFaintedTemplateText))
+	noPPLeftTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noPPLeftTemplateText))
+	viewDexTemplate = template.Must(template.New("").Funcs(funcMap).Parse(viewDexTemplateText))
+	dexEntryTemplate = template.Must(template.New("").Funcs(funcMap).Parse(dexEntryTemplateText))
+	noSuchSpeciesTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noSuchSpeciesTemplateText))
+	speciesNotSeenTemplate = template.Must(template.New("").Funcs(funcMap).Parse(speciesNotSeenTemplateText))
+	viewAreasTemplate = template.Must(template.New("").Funcs(funcMap).Parse(viewAreasTemplateText))
+	noSuchAreaTemplate = template.Must(template.New("").Funcs(funcMap).Parse(noSuchAreaTemplateText))
+	areaLockedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(areaLockedTemplateText))
+	traveledTemplate = template.Must(template.New("").Funcs(funcMap).Parse(traveledTemplateText))
+	prizeMoneyTemplate = template.Must(template.New("").Funcs(funcMap).Parse(prizeMoneyTemplateText))
+	prizeMoneyPaidTemplate = template.Must(template.New("").Funcs(funcMap).Parse(prizeMoneyPaidTemplateText))
+	viewMartTemplate = template.Must(template.New("").Funcs(funcMap).Parse(viewMartTemplateText))
+	notSoldAtMartTemplate = template.Must(template.New("").Funcs(funcMap).Parse(notSoldAtMartTemplateText))
+	martItemLockedTemplate = template.Must(template.New("").Funcs(funcMap).Parse(martItemLockedTemplateText))
+	invalidPurchaseCountTemplate = template.Must(template.New("").Funcs(funcMap).Parse(invalidPurchaseCountTemplateText))
+	notEnoughMoneyTemplate = template.Must(template.New("").Funcs(funcMap).Parse(notEnoughMoneyTemplateText))
+	itemsBoughtTemplate = template.Must(template.New("").Funcs(funcMap).Parse(itemsBoughtTemplateText))
+	evolutionCancelledTemplate = template.Must(template.New("").Funcs(funcMap).Parse(evolutionCancelledTemplateText))
 }
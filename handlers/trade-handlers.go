@@ -0,0 +1,439 @@
+package handlers
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/database"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// partyPokemonByUUID returns the slot of the Pokemon with the given UUID in
+// the trainer's party, or false if it isn't in the party.
+func partyPokemonByUUID(t *basicTrainerData, uuid string) (int, bool) {
+	for slot, partyMember := range t.pkmn {
+		if partyMember.GetPokemon().UUID == uuid {
+			return slot, true
+		}
+	}
+	return -1, false
+}
+
+// loadTradePartner loads the trainer the given trainer is trading with.
+func loadTradePartner(ctx context.Context, db database.Database, t *basicTrainerData) (*basicTrainerData, error) {
+	return loadBasicTrainerData(ctx, db, t.trainer.GetTrainer().Trade.Partner)
+}
+
+// cancelTrade withdraws both trainers' sides of the trade between them.
+func cancelTrade(t1, t2 *basicTrainerData) {
+	if t1.trainer.GetTrainer().Trade.Partner == t2.trainer.GetTrainer().UUID {
+		t1.trainer.GetTrainer().CancelTrade()
+	}
+	if t2.trainer.GetTrainer().Trade.Partner == t1.trainer.GetTrainer().UUID {
+		t2.trainer.GetTrainer().CancelTrade()
+	}
+}
+
+// checkTradersWaiting makes sure neither trainer is in a battle or otherwise
+// busy, since the party of a busy trainer can't change. If one of them is,
+// the trade between them is cancelled, the requester is told so and false is
+// returned.
+func checkTradersWaiting(ctx context.Context, s Services, requester, partner *basicTrainerData) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	busy := requester
+	if requester.trainer.GetTrainer().Mode == pkmn.WaitingTrainerMode {
+		if partner.trainer.GetTrainer().Mode == pkmn.WaitingTrainerMode {
+			return true, nil
+		}
+		busy = partner
+	}
+
+	cancelTrade(requester, partner)
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     traderBusyTemplate,
+		TemplInfo: busy.trainer.GetTrainer().Name})
+	if err != nil {
+		return false, handlerError{user: "could not populate trader busy template", err: err}
+	}
+
+	// Save both trainers
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return false, handlerError{user: "could not save trainer data", err: err}
+	}
+	err = s.DB.SaveTrainer(ctx, partner.trainer)
+	if err != nil {
+		return false, handlerError{user: "could not save the trade partner's data", err: err}
+	}
+
+	return false, nil
+}
+
+// executeTrade swaps the Pokemon the two trainers offered each other, then
+// evolves any of them that evolve by being traded. It must be run inside a
+// transaction so that the Pokemon can't end up with both trainers or neither.
+func executeTrade(ctx context.Context, s Services, t1, t2 *basicTrainerData, slot1, slot2 int) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	p1 := t1.pkmn[slot1]
+	p2 := t2.pkmn[slot2]
+
+	// Take the Pokemon away from their old trainers
+	err := s.DB.DeletePokemon(ctx, t1.trainer, p1.GetPokemon().UUID)
+	if err != nil {
+		return err
+	}
+	err = s.DB.DeletePokemon(ctx, t2.trainer, p2.GetPokemon().UUID)
+	if err != nil {
+		return err
+	}
+	p1.GetPokemon().MarkTraded(t1.trainer.GetTrainer())
+	p2.GetPokemon().MarkTraded(t2.trainer.GetTrainer())
+
+	// Give the Pokemon to their new trainers, in the same slot as the Pokemon
+	// they were traded for
+	t1.pkmn[slot1] = p2
+	t2.pkmn[slot2] = p1
+	t1.trainer.GetTrainer().Pokedex.Catch(p2.GetPokemon().ID)
+	t2.trainer.GetTrainer().Pokedex.Catch(p1.GetPokemon().ID)
+	cancelTrade(t1, t2)
+
+	templInfo := struct {
+		Trainer1Name string
+		Pokemon1Name string
+		Trainer2Name string
+		Pokemon2Name string
+	}{
+		Trainer1Name: t1.trainer.GetTrainer().Name,
+		Pokemon1Name: p1.GetPokemon().DisplayName(),
+		Trainer2Name: t2.trainer.GetTrainer().Name,
+		Pokemon2Name: p2.GetPokemon().DisplayName()}
+	err = messaging.SendTempl(client, t1.lastContactURL, messaging.TemplMessage{
+		Type:      messaging.Good,
+		Public:    true,
+		Templ:     tradeCompleteTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return err
+	}
+
+	// Some Pokemon evolve when they're traded
+	_, err = evolveIfTraded(ctx, s, t1, p2)
+	if err != nil {
+		return err
+	}
+	_, err = evolveIfTraded(ctx, s, t2, p1)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Trade manages requests to offer a Pokemon in the party to another trainer.
+// If that trainer has already offered a Pokemon in return, both trainers are
+// asked to confirm the trade.
+type Trade struct {
+}
+
+func (h *Trade) preprocess(ctx context.Context, s Services) (context.Context, bool, error) {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Check if the command was used correctly
+	if len(slackReq.CommandParams) != 2 {
+		return ctx, false, sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Look up the UUID of the trainer to trade with. This is done outside of
+	// the transaction because it can't be done with an ancestor query
+	partnerName := strings.TrimPrefix(slackReq.CommandParams[0], "@")
+	partnerUUID, err := s.DB.LoadUUIDFromHumanTrainerName(ctx, partnerName)
+	if database.IsNoResults(err) {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     noSuchTrainerExistsTemplate,
+			TemplInfo: partnerName})
+		if err != nil {
+			return ctx, false, handlerError{user: "could not populate no such trainer exists template", err: err}
+		}
+		return ctx, false, nil // This request has been finished
+	} else if err != nil {
+		return ctx, false, handlerError{user: "could not convert the partner's username to a UUID", err: err}
+	}
+
+	ctx = context.WithValue(ctx, "partner UUID", partnerUUID)
+
+	return ctx, true, nil
+}
+
+func (h *Trade) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+	partnerUUID := ctx.Value("partner UUID").(string)
+
+	// Trainers can't trade with themselves
+	if requester.trainer.GetTrainer().UUID == partnerUUID {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     noTradingSelfTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate no trading self template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Extract the party slot ID from the command
+	partySlotID, ok, err := parsePartySlotID(client, requester, slackReq.CommandParams[1])
+	if err != nil || !ok {
+		return err
+	}
+	offered := requester.pkmn[partySlotID-1].GetPokemon()
+
+	// Load some info on the trade partner
+	partner, err := loadBasicTrainerData(ctx, s.DB, partnerUUID)
+	if err != nil {
+		return handlerError{user: "could not load the trade partner's information", err: err}
+	}
+
+	// Pokemon can't be offered to or by a trainer who's busy
+	ok, err = checkTradersWaiting(ctx, s, requester, partner)
+	if err != nil || !ok {
+		return err
+	}
+
+	// Make the offer. Any confirmation the partner gave was for a different
+	// offer, so they have to confirm again
+	requester.trainer.GetTrainer().Trade = pkmn.TradeOffer{
+		Partner: partnerUUID,
+		Pokemon: offered.UUID}
+	if partner.trainer.GetTrainer().Trade.Partner == requester.trainer.GetTrainer().UUID {
+		partner.trainer.GetTrainer().Trade.Confirmed = false
+	}
+
+	if pkmn.TradeAgreed(requester.trainer.GetTrainer(), partner.trainer.GetTrainer()) {
+		// Both trainers have made an offer, so they just have to confirm
+		partnerSlot, ok := partyPokemonByUUID(partner, partner.trainer.GetTrainer().Trade.Pokemon)
+		if !ok {
+			// The partner no longer has the Pokemon they offered
+			partner.trainer.GetTrainer().CancelTrade()
+		} else {
+			templInfo := struct {
+				RequesterName string
+				OfferedName   string
+				OfferedLevel  int
+				PartnerName   string
+				ReturnedName  string
+				ReturnedLevel int
+				SlashCommand  string
+			}{
+				RequesterName: requester.trainer.GetTrainer().Name,
+				OfferedName:   offered.DisplayName(),
+				OfferedLevel:  offered.Level,
+				PartnerName:   partner.trainer.GetTrainer().Name,
+				ReturnedName:  partner.pkmn[partnerSlot].GetPokemon().DisplayName(),
+				ReturnedLevel: partner.pkmn[partnerSlot].GetPokemon().Level,
+				SlashCommand:  slackReq.SlashCommand}
+			err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+				Type:      messaging.Important,
+				Public:    true,
+				Templ:     tradeAgreedTemplate,
+				TemplInfo: templInfo})
+			if err != nil {
+				return handlerError{user: "could not populate trade agreed template", err: err}
+			}
+		}
+	}
+	if !pkmn.TradeAgreed(requester.trainer.GetTrainer(), partner.trainer.GetTrainer()) {
+		// Wait for the partner to offer something in return
+		templInfo := struct {
+			RequesterName string
+			OfferedName   string
+			OfferedLevel  int
+			PartnerName   string
+			SlashCommand  string
+		}{
+			RequesterName: requester.trainer.GetTrainer().Name,
+			OfferedName:   offered.DisplayName(),
+			OfferedLevel:  offered.Level,
+			PartnerName:   partner.trainer.GetTrainer().Name,
+			SlashCommand:  slackReq.SlashCommand}
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Type:      messaging.Important,
+			Public:    true,
+			Templ:     tradeOfferedTemplate,
+			TemplInfo: templInfo,
+			Image:     offered.Sprite()})
+		if err != nil {
+			return handlerError{user: "could not populate trade offered template", err: err}
+		}
+	}
+
+	// Save both trainers
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+	err = s.DB.SaveTrainer(ctx, partner.trainer)
+	if err != nil {
+		return handlerError{user: "could not save the trade partner's data", err: err}
+	}
+
+	return nil
+}
+
+// ConfirmTrade manages requests to agree to the trade the trainer is in. The
+// Pokemon are exchanged once both trainers have confirmed.
+type ConfirmTrade struct {
+}
+
+func (h *ConfirmTrade) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Make sure there's a trade to confirm
+	if !requester.trainer.GetTrainer().Trading() {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notTradingTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate not trading template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	partner, err := loadTradePartner(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not load the trade partner's information", err: err}
+	}
+
+	// The partner may have started a battle since they made their offer, and
+	// the Pokemon in a battle can't change hands
+	ok, err := checkTradersWaiting(ctx, s, requester, partner)
+	if err != nil || !ok {
+		return err
+	}
+
+	// The partner has to offer something before the trade can be confirmed
+	if !pkmn.TradeAgreed(requester.trainer.GetTrainer(), partner.trainer.GetTrainer()) {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     tradeNotAgreedTemplate,
+			TemplInfo: partner.trainer.GetTrainer().Name})
+		if err != nil {
+			return handlerError{user: "could not populate trade not agreed template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Both Pokemon have to still be in their trainers' parties
+	requesterSlot, requesterOK := partyPokemonByUUID(requester, requester.trainer.GetTrainer().Trade.Pokemon)
+	partnerSlot, partnerOK := partyPokemonByUUID(partner, partner.trainer.GetTrainer().Trade.Pokemon)
+	if !requesterOK || !partnerOK {
+		cancelTrade(requester, partner)
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Public:    true,
+			Templ:     tradeFailedTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate trade failed template", err: err}
+		}
+	} else {
+		requester.trainer.GetTrainer().Trade.Confirmed = true
+
+		if pkmn.TradeReady(requester.trainer.GetTrainer(), partner.trainer.GetTrainer()) {
+			// The Runner runs every task in a transaction, so the exchange
+			// either happens completely or not at all
+			err = executeTrade(ctx, s, requester, partner, requesterSlot, partnerSlot)
+			if err != nil {
+				return handlerError{user: "could not trade the Pokemon", err: err}
+			}
+		} else {
+			templInfo := struct {
+				RequesterName string
+				PartnerName   string
+			}{
+				RequesterName: requester.trainer.GetTrainer().Name,
+				PartnerName:   partner.trainer.GetTrainer().Name}
+			err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+				Public:    true,
+				Templ:     tradeConfirmedTemplate,
+				TemplInfo: templInfo})
+			if err != nil {
+				return handlerError{user: "could not populate trade confirmed template", err: err}
+			}
+		}
+	}
+
+	// Save both trainers and their parties
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+	err = saveBasicTrainerData(ctx, s.DB, partner)
+	if err != nil {
+		return handlerError{user: "could not save the trade partner's data", err: err}
+	}
+
+	return nil
+}
+
+// CancelTrade manages requests to back out of the trade the trainer is in.
+type CancelTrade struct {
+}
+
+func (h *CancelTrade) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	// Make sure there's a trade to cancel
+	if !requester.trainer.GetTrainer().Trading() {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notTradingTemplate,
+			TemplInfo: nil})
+		if err != nil {
+			return handlerError{user: "could not populate not trading template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+	partner, err := loadTradePartner(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not load the trade partner's information", err: err}
+	}
+
+	cancelTrade(requester, partner)
+
+	templInfo := struct {
+		RequesterName string
+		PartnerName   string
+	}{
+		RequesterName: requester.trainer.GetTrainer().Name,
+		PartnerName:   partner.trainer.GetTrainer().Name}
+	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Public:    true,
+		Templ:     tradeCancelledTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate trade cancelled template", err: err}
+	}
+
+	// Save both trainers
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+	err = s.DB.SaveTrainer(ctx, partner.trainer)
+	if err != nil {
+		return handlerError{user: "could not save the trade partner's data", err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// Travel manages requests to travel to an area, which decides where wild
+// Pokemon are found. With no parameters, the areas the trainer can travel to
+// are listed instead.
+type Travel struct {
+	Services
+}
+
+func (h *Travel) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	if len(slackReq.CommandParams) == 0 {
+		// List every area and whether the trainer can go there
+		type areaEntry struct {
+			Name     string
+			Unlocked bool
+			Badges   int
+		}
+		type regionEntry struct {
+			Name  string
+			Areas []areaEntry
+		}
+		currRegion, currArea := t.CurrentArea()
+		templInfo := struct {
+			Region       string
+			Area         string
+			Regions      []regionEntry
+			SlashCommand string
+		}{
+			Region:       strings.Title(currRegion.Name()),
+			Area:         currArea.Name,
+			SlashCommand: slackReq.SlashCommand}
+		for r := pkmn.Region(0); int(r) < pkmn.RegionCount; r++ {
+			entry := regionEntry{Name: r.Name()}
+			for _, area := range pkmn.Areas(r) {
+				entry.Areas = append(entry.Areas, areaEntry{
+					Name:     area.Name,
+					Unlocked: t.CanTravel(r, area),
+					Badges:   area.Badges})
+			}
+			templInfo.Regions = append(templInfo.Regions, entry)
+		}
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     viewAreasTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate view areas template", err: err}
+		}
+		return nil
+	}
+
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) != 2 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the region to travel to
+	regionName := strings.ToLower(slackReq.CommandParams[0])
+	region, ok := pkmn.NameToRegion(regionName)
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     noSuchRegionTemplate,
+			TemplInfo: regionName})
+		if err != nil {
+			return handlerError{user: "could not populate no such region template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Find the area in the region
+	areaName := strings.ToLower(slackReq.CommandParams[1])
+	area, ok := pkmn.FindArea(region, areaName)
+	if !ok {
+		templInfo := struct {
+			Region       string
+			Area         string
+			SlashCommand string
+		}{
+			Region:       strings.Title(region.Name()),
+			Area:         areaName,
+			SlashCommand: slackReq.SlashCommand}
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     noSuchAreaTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate no such area template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	templInfo := struct {
+		Region string
+		Area   string
+		Badges int
+	}{
+		Region: strings.Title(region.Name()),
+		Area:   area.Name,
+		Badges: area.Badges}
+
+	// Areas have to be unlocked with badges first
+	if !t.CanTravel(region, area) {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     areaLockedTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate area locked template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	t.Travel(region, area)
+
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     traveledTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate traveled template", err: err}
+	}
+
+	// Save the trainer
+	err = s.DB.SaveTrainer(ctx, requester.trainer)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
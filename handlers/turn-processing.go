@@ -1,8 +1,7 @@
 package handlers
 
 import (
-	"errors"
-	"math/rand"
+	"strings"
 	"text/template"
 
 	"github.com/velovix/snoreslacks/messaging"
@@ -29,26 +28,30 @@ func preprocessTurn(ctx context.Context, s Services, bd *battleData) (bool, erro
 
 		// The opponent has not picked their action yet
 		return false, nil
-	case pkmn.WildTrainerType:
-		// The opponent is a wild Pokemon, so their move will be chosen
-		// algorithmically
-		opponentPkmn := bd.opponent.activePkmn()
-		moveCnt := opponentPkmn.GetPokemon().MoveCount()
-		moves := opponentPkmn.GetPokemon().MoveIDsAsSlice()
-
-		// Find move
-		moveID, err := bd.opponent.trainer.GetTrainer().PickMove(moves, moveCnt)
-		if err != nil {
-			return false, err
+	case pkmn.WildTrainerType, pkmn.GymLeaderTrainerType:
+		// The opponent is a bot, so their action will be chosen by their
+		// strategy, unless their Pokemon has to keep using a move
+		var action pkmn.BattleAction
+		if moveID, forced := bd.opponent.activePkmnBattleInfo().GetPokemonBattleInfo().ForcedMove(); forced {
+			action = pkmn.BattleAction{
+				Type: pkmn.MoveBattleActionType,
+				Val:  moveID}
+		} else {
+			strategy, err := strategyFor(bd.opponent.trainer.GetTrainer())
+			if err != nil {
+				return false, err
+			}
+			action, err = strategy.pickAction(ctx, s, bd, bd.opponent, bd.requester)
+			if err != nil {
+				return false, err
+			}
 		}
 
-		s.Log.Infof(ctx, "wild opponent will be using a move: %v", moveID)
+		s.Log.Infof(ctx, "bot opponent will be taking an action: %+v", action)
 
-		// Set up the next action to be a move action
+		// Set up the next action
 		bd.opponent.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
-		bd.opponent.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
-			Type: pkmn.MoveBattleActionType,
-			Val:  moveID}
+		bd.opponent.battleInfo.GetTrainerBattleInfo().NextBattleAction = action
 
 		// Now that the opponent has their choice of action ready, the turn is
 		// ready to be processed
@@ -77,13 +80,13 @@ func (tp *turnProcessor) checkIfPlayerLost(ctx context.Context, bd *battleData,
 	return true, nil
 }
 
-// Runs a move action for a single player. Public is true if the messages
-// resulting from these actions should be public.
+// Runs a move action for a single player in the given battle. Public is true
+// if the messages resulting from these actions should be public.
 //
 // The first return value is true if the trainer running a turn after this one
 // (if this is not the last turn) should continue their turn, and false
 // otherwise. This might be false if this move made the other Pokemon faint.
-func (tp *turnProcessor) runMove(ctx context.Context, public bool, user, target *battleTrainerData, move pkmn.Move) (bool, error) {
+func (tp *turnProcessor) runMove(ctx context.Context, public bool, b *pkmn.Battle, user, target *battleTrainerData, move pkmn.Move) (bool, error) {
 	// Load request-specific objects
 	client := ctx.Value("client").(messaging.Client)
 
@@ -101,12 +104,28 @@ func (tp *turnProcessor) runMove(ctx context.Context, public bool, user, target
 		return false, nil
 	}
 
+	// Moves the Pokemon is forced to keep using were already paid for on the
+	// turn they started
+	_, forced := user.activePkmnBattleInfo().GetPokemonBattleInfo().ForcedMove()
+
 	// Use the move
-	mr, err = pkmn.RunMove(user.activePkmn().GetPokemon(), target.activePkmn().GetPokemon(),
-		user.activePkmnBattleInfo().GetPokemonBattleInfo(), target.activePkmnBattleInfo().GetPokemonBattleInfo(), move)
+	mr, err = pkmn.RunMove(b, user.activePkmn().GetPokemon(), target.activePkmn().GetPokemon(),
+		user.activePkmnBattleInfo().GetPokemonBattleInfo(), target.activePkmnBattleInfo().GetPokemonBattleInfo(),
+		user.battleInfo.GetTrainerBattleInfo(), target.battleInfo.GetTrainerBattleInfo(), move)
 	if err != nil {
 		return false, handlerError{user: "could not run move", err: err}
 	}
+	if !forced {
+		user.activePkmn().GetPokemon().UsePP(move.ID)
+	}
+
+	// Find whose side of the field any side condition the move created is on.
+	// Entry hazards are laid on the opponent's side and everything else
+	// protects the user's side.
+	sideOwner := user.trainer.GetTrainer()
+	if mr.SideConditionStarted.IsHazard() {
+		sideOwner = target.trainer.GetTrainer()
+	}
 
 	// The caller always assumes that the target is the opponent, but sometimes
 	// it's the same as the user. Correct it if that's the case.
@@ -118,14 +137,8 @@ func (tp *turnProcessor) runMove(ctx context.Context, public bool, user, target
 	// in question. For instance, "the wild bulbsaur used tackle" or
 	// "ash.ketchum's pikachu is poisoned!". We need to figuire out which of
 	// these prefixes is appopriate for each Pokemon
-	userActionPrefix := user.trainer.GetTrainer().Name + "'s"
-	if user.trainer.GetTrainer().Type == pkmn.WildTrainerType {
-		userActionPrefix = "The wild"
-	}
-	targetActionPrefix := target.trainer.GetTrainer().Name + "'s"
-	if target.trainer.GetTrainer().Type == pkmn.WildTrainerType {
-		targetActionPrefix = "The wild"
-	}
+	userActionPrefix := pokemonActionPrefix(user.trainer.GetTrainer())
+	targetActionPrefix := pokemonActionPrefix(target.trainer.GetTrainer())
 
 	// Send the move report
 	templInfo := struct {
@@ -139,10 +152,10 @@ func (tp *turnProcessor) runMove(ctx context.Context, public bool, user, target
 	}{
 		MoveReport:         mr,
 		UserActionPrefix:   userActionPrefix,
-		UserPokemonName:    user.activePkmn().GetPokemon().Name,
+		UserPokemonName:    user.activePkmn().GetPokemon().DisplayName(),
 		TargetHPBar:        makeTextHPBar(target.activePkmn().GetPokemon(), target.activePkmnBattleInfo().GetPokemonBattleInfo()),
 		TargetActionPrefix: targetActionPrefix,
-		TargetPokemonName:  target.activePkmn().GetPokemon().Name,
+		TargetPokemonName:  target.activePkmn().GetPokemon().DisplayName(),
 		MoveName:           move.Name}
 	err = messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
 		Templ:     moveReportTemplate,
@@ -152,6 +165,37 @@ func (tp *turnProcessor) runMove(ctx context.Context, public bool, user, target
 		return false, handlerError{user: "could not populate move report template", err: err}
 	}
 
+	// Announce any weather the move started
+	if mr.WeatherStarted != pkmn.NoWeather {
+		err = sendWeatherReport(client, user.lastContactURL, public, mr.WeatherStarted, true, false)
+		if err != nil {
+			return false, handlerError{user: "could not populate weather report template", err: err}
+		}
+	}
+
+	// Announce any side condition the move created
+	if mr.SideConditionStarted != pkmn.NoSideCondition {
+		err = sendSideConditionReport(client, user.lastContactURL, public, mr.SideConditionStarted, sideOwner, true)
+		if err != nil {
+			return false, handlerError{user: "could not populate side condition report template", err: err}
+		}
+	}
+
+	// Report on the target's ability if it activated during the move
+	err = sendAbilityReport(client, user.lastContactURL, public, mr.TargetAbility,
+		target.trainer.GetTrainer(), target.activePkmn().GetPokemon(),
+		user.trainer.GetTrainer(), user.activePkmn().GetPokemon())
+	if err != nil {
+		return false, handlerError{user: "could not populate ability report template", err: err}
+	}
+
+	// Report on the target's held item if it activated during the move
+	err = sendHeldItemReport(client, user.lastContactURL, public, mr.TargetHeldItem,
+		target.trainer.GetTrainer(), target.activePkmn().GetPokemon())
+	if err != nil {
+		return false, handlerError{user: "could not populate held item report template", err: err}
+	}
+
 	if mr.TargetFainted {
 		// The target fainted, so we need to tell the caller that this move
 		// should mark the end of the turn
@@ -161,8 +205,9 @@ func (tp *turnProcessor) runMove(ctx context.Context, public bool, user, target
 	return true, nil
 }
 
-// Runs a switch action for a single player.
-func (tp *turnProcessor) runSwitch(ctx context.Context, public bool, user *battleTrainerData) error {
+// Runs a switch action for a single player in the given battle. The target is
+// the trainer the user is battling.
+func (tp *turnProcessor) runSwitch(ctx context.Context, public bool, b *pkmn.Battle, user, target *battleTrainerData) error {
 	// Load request-specific objects
 	client := ctx.Value("client").(messaging.Client)
 
@@ -170,6 +215,11 @@ func (tp *turnProcessor) runSwitch(ctx context.Context, public bool, user *battl
 	prevPkmn := user.battleInfo.GetTrainerBattleInfo().CurrPkmnSlot
 	newPkmn := user.battleInfo.GetTrainerBattleInfo().NextBattleAction.Val
 	user.battleInfo.GetTrainerBattleInfo().CurrPkmnSlot = newPkmn
+	target.trainer.GetTrainer().Pokedex.See(user.pkmn[newPkmn].GetPokemon().ID)
+
+	// The withdrawn Pokemon is no longer locked into a move by its Choice
+	// Band or anything else
+	user.pkmnBattleInfo[prevPkmn].GetPokemonBattleInfo().SwitchOut()
 
 	var err error
 
@@ -181,8 +231,8 @@ func (tp *turnProcessor) runSwitch(ctx context.Context, public bool, user *battl
 		SelectedLevel    int
 	}{
 		Switcher:         user.trainer.GetTrainer().Name,
-		WithdrawnPokemon: user.pkmn[prevPkmn].GetPokemon().Name,
-		SelectedPokemon:  user.pkmn[newPkmn].GetPokemon().Name,
+		WithdrawnPokemon: user.pkmn[prevPkmn].GetPokemon().DisplayName(),
+		SelectedPokemon:  user.pkmn[newPkmn].GetPokemon().DisplayName(),
 		SelectedLevel:    user.pkmn[newPkmn].GetPokemon().Level}
 	err = messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
 		Public:    public,
@@ -192,42 +242,123 @@ func (tp *turnProcessor) runSwitch(ctx context.Context, public bool, user *battl
 		return handlerError{user: "could not populate switch Pokemon template", err: err}
 	}
 
-	// Send the new action options to the requester
-	err = makeActionOptions(ctx, tp.Services, user.basicTrainerData, user.battleInfo)
+	// Apply the effects of entering the battle
+	err = tp.runEntry(ctx, public, b, user, target)
+	if err != nil {
+		return err
+	}
+
+	// Send the new action options to the requester. Bots can switch too, but
+	// they have no use for them.
+	if user.trainer.GetTrainer().Type == pkmn.HumanTrainerType {
+		err = makeActionOptions(ctx, tp.Services, b, user.basicTrainerData, user.battleInfo)
+		if err != nil {
+			return handlerError{user: "could not send action options", err: err}
+		}
+	}
+
+	return nil
+}
+
+// runEntry applies the effects of the user's active Pokemon entering the battle
+// against the target, which are the entry hazards on the user's side of the
+// field and the Pokemon's switch-in ability.
+func (tp *turnProcessor) runEntry(ctx context.Context, public bool, b *pkmn.Battle, user, target *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	// Apply the effects of the entry hazards on the user's side of the field
+	hr, err := pkmn.RunEntryHazards(user.activePkmn().GetPokemon(), user.activePkmnBattleInfo().GetPokemonBattleInfo(),
+		user.battleInfo.GetTrainerBattleInfo())
+	if err != nil {
+		return handlerError{user: "could not apply entry hazards", err: err}
+	}
+	if hr.Activated() {
+		hazardTemplInfo := struct {
+			pkmn.HazardReport
+			ActionPrefix string
+			PokemonName  string
+			HPBar        string
+		}{
+			HazardReport: hr,
+			ActionPrefix: pokemonActionPrefix(user.trainer.GetTrainer()),
+			PokemonName:  user.activePkmn().GetPokemon().DisplayName(),
+			HPBar:        makeTextHPBar(user.activePkmn().GetPokemon(), user.activePkmnBattleInfo().GetPokemonBattleInfo())}
+		err = messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
+			Public:    public,
+			Templ:     hazardReportTemplate,
+			TemplInfo: hazardTemplInfo})
+		if err != nil {
+			return handlerError{user: "could not populate hazard report template", err: err}
+		}
+	}
+
+	if user.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
+		// The hazards made the Pokemon faint, so its ability never gets a
+		// chance to activate
+		return nil
+	}
+
+	// Apply the switch-in effects of the new Pokemon's ability
+	ar := pkmn.RunSwitchInAbility(b, user.activePkmn().GetPokemon(), target.activePkmn().GetPokemon(),
+		user.activePkmnBattleInfo().GetPokemonBattleInfo(), target.activePkmnBattleInfo().GetPokemonBattleInfo())
+	err = sendAbilityReport(client, user.lastContactURL, public, ar,
+		user.trainer.GetTrainer(), user.activePkmn().GetPokemon(),
+		target.trainer.GetTrainer(), target.activePkmn().GetPokemon())
 	if err != nil {
-		return handlerError{user: "could not send action options", err: err}
+		return handlerError{user: "could not populate ability report template", err: err}
 	}
 
 	return nil
 }
 
-// Runs a catch action for a single player
-func (tp *turnProcessor) runCatch(ctx context.Context, public bool, user, target *battleTrainerData) (bool, error) {
+// Runs a catch action for a single player. The battle is used to find the
+// conditions the ball is thrown in.
+func (tp *turnProcessor) runCatch(ctx context.Context, public bool, b *pkmn.Battle, user, target *battleTrainerData) (bool, error) {
 	// Load request-specific objects
 	client := ctx.Value("client").(messaging.Client)
 
-	// Find the catch rate of the trainer's current Pokemon
-	catchRate := pkmn.CatchRate(*target.activePkmn().GetPokemon(),
-		*target.activePkmnBattleInfo().GetPokemonBattleInfo())
-	// Generate a random number indicating whether or not the user caught the
-	// Pokemon
-	catchScore := rand.Float64() * 256.0
+	// Take the ball from the trainer's bag
+	ball := pkmn.Item(user.battleInfo.GetTrainerBattleInfo().NextBattleAction.Val)
+	err := user.trainer.GetTrainer().TakeItem(ball)
+	if err != nil {
+		return false, handlerError{user: "you don't have any " + ball.Name(), err: err}
+	}
+
+	// Throw the ball
+	cond := pkmn.CatchConditions{
+		Turn:          b.Turn,
+		Night:         pkmn.TimeOfDayAt(tp.Clock.Now()) == pkmn.NightTimeOfDay,
+		SpeciesCaught: len(user.trainer.GetTrainer().Pokedex.Caught)}
+	cr := pkmn.ThrowBall(*target.activePkmn().GetPokemon(),
+		*target.activePkmnBattleInfo().GetPokemonBattleInfo(), ball, cond)
 
 	// Whether or not the user caught the Pokemon decides the template we will
 	// send them
 	var success bool
 	var templ *template.Template
-	if catchScore <= catchRate {
+	var boxID int
+	if cr.Caught {
 		// The Pokemon was caught
 		success = true
 		templ = pokemonCaughtTemplate
+		// The caught Pokemon keeps the HP and status it was caught with
+		target.activePkmn().GetPokemon().KeepCondition(*target.activePkmnBattleInfo().GetPokemonBattleInfo())
 		// The caught Pokemon is essentially out of commission for this battle
 		target.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP = 0
-		// Give the Pokemon to the trainer
+		// Give the Pokemon to the trainer, sending it to the PC if their
+		// party is full
 		tp.Log.Infof(ctx, "giving %v the %v", user.trainer.GetTrainer().Name, target.activePkmn().GetPokemon().Name)
-		user.pkmn, success = givePokemon(user.pkmn, tp.DB.NewPokemon(*target.activePkmn().GetPokemon()))
-		if !success {
-			return false, handlerError{user: "trainer already has the maximum amount of Pokemon", err: errors.New("trainer already has the maximum amount of Pokemon")}
+		caught := tp.DB.NewPokemon(*target.activePkmn().GetPokemon())
+		user.trainer.GetTrainer().Pokedex.Catch(caught.GetPokemon().ID)
+		var given bool
+		user.pkmn, given = givePokemon(user.pkmn, caught)
+		if !given {
+			box, err := boxPokemon(ctx, tp.DB, user.trainer, caught, tp.Clock.Now())
+			if err != nil {
+				return false, handlerError{user: "could not send the Pokemon to the PC", err: err}
+			}
+			boxID = box + 1
 		}
 	} else {
 		// The Pokemon was not caught
@@ -236,10 +367,24 @@ func (tp *turnProcessor) runCatch(ctx context.Context, public bool, user, target
 	}
 
 	// Send the message containing the results
-	err := messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
+	templInfo := struct {
+		pkmn.CatchReport
+		PokemonName string
+		Form        string
+		Shiny       bool
+		BallName    string
+		BoxID       int
+	}{
+		CatchReport: cr,
+		PokemonName: target.activePkmn().GetPokemon().DisplayName(),
+		Form:        target.activePkmn().GetPokemon().Form,
+		Shiny:       target.activePkmn().GetPokemon().Shiny,
+		BallName:    ball.Name(),
+		BoxID:       boxID}
+	err = messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
 		Public:    public,
 		Templ:     templ,
-		TemplInfo: target.activePkmn().GetPokemon().Name})
+		TemplInfo: templInfo})
 	if err != nil {
 		return false, handlerError{user: "could not populate a Pokemon caught template", err: err}
 	}
@@ -248,19 +393,543 @@ func (tp *turnProcessor) runCatch(ctx context.Context, public bool, user, target
 	return !success, nil
 }
 
-// runTurn runs the turn of the user on the target. It returns true if the turn
-// should continue.
+// Runs an item action for a single player, using an item from their bag on one
+// of their Pokemon.
+func (tp *turnProcessor) runItem(ctx context.Context, public bool, user *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	action := user.battleInfo.GetTrainerBattleInfo().NextBattleAction
+	item := pkmn.Item(action.Val)
+	p := user.pkmn[action.Target].GetPokemon()
+	pBI := user.pkmnBattleInfo[action.Target].GetPokemonBattleInfo()
+
+	// Use the item
+	ir, err := pkmn.UseMedicine(item, p, pBI)
+	if err != nil {
+		return handlerError{user: "could not use item", err: err}
+	}
+	// Items are only used up if they had an effect
+	if ir.Activated {
+		err = user.trainer.GetTrainer().TakeItem(item)
+		if err != nil {
+			return handlerError{user: "you don't have any " + item.Name(), err: err}
+		}
+	}
+
+	// Send the item report
+	templInfo := struct {
+		pkmn.ItemReport
+		TrainerName string
+		ItemName    string
+		PokemonName string
+	}{
+		ItemReport:  ir,
+		TrainerName: user.trainer.GetTrainer().Name,
+		ItemName:    item.Name(),
+		PokemonName: p.DisplayName()}
+	err = messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
+		Public:    public,
+		Templ:     itemUsedTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate item used template", err: err}
+	}
+
+	return nil
+}
+
+// runEndOfTurn applies the end of turn effects of the holder's active Pokemon's
+// ability and held item, so long as it is still able to fight.
+func (tp *turnProcessor) runEndOfTurn(ctx context.Context, public bool, holder, opponent *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	if holder.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
+		// Fainted Pokemon's abilities have no effect
+		return nil
+	}
+
+	ar := pkmn.RunEndOfTurnAbility(holder.activePkmn().GetPokemon(), holder.activePkmnBattleInfo().GetPokemonBattleInfo())
+	err := sendAbilityReport(client, holder.lastContactURL, public, ar,
+		holder.trainer.GetTrainer(), holder.activePkmn().GetPokemon(),
+		opponent.trainer.GetTrainer(), opponent.activePkmn().GetPokemon())
+	if err != nil {
+		return handlerError{user: "could not populate ability report template", err: err}
+	}
+
+	ir := pkmn.RunEndOfTurnHeldItem(holder.activePkmn().GetPokemon(), holder.activePkmnBattleInfo().GetPokemonBattleInfo())
+	err = sendHeldItemReport(client, holder.lastContactURL, public, ir,
+		holder.trainer.GetTrainer(), holder.activePkmn().GetPokemon())
+	if err != nil {
+		return handlerError{user: "could not populate held item report template", err: err}
+	}
+
+	return nil
+}
+
+// runWeatherDamage deals the end of turn damage of the battle's weather to the
+// victim's active Pokemon and reports it if any damage was done.
+func (tp *turnProcessor) runWeatherDamage(ctx context.Context, public bool, b *pkmn.Battle, victim *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	p := victim.activePkmn().GetPokemon()
+	pBI := victim.activePkmnBattleInfo().GetPokemonBattleInfo()
+
+	damage := pkmn.RunWeatherDamage(b, p, pBI)
+	if damage == 0 {
+		// The Pokemon wasn't affected by the weather
+		return nil
+	}
+
+	templInfo := struct {
+		ActionPrefix string
+		PokemonName  string
+		Weather      string
+		Damage       int
+		Fainted      bool
+		HPBar        string
+	}{
+		ActionPrefix: pokemonActionPrefix(victim.trainer.GetTrainer()),
+		PokemonName:  p.DisplayName(),
+		Weather:      b.Weather.Name(),
+		Damage:       damage,
+		Fainted:      pBI.CurrHP <= 0,
+		HPBar:        makeTextHPBar(p, pBI)}
+	err := messaging.SendTempl(client, victim.lastContactURL, messaging.TemplMessage{
+		Public:    public,
+		Templ:     weatherDamageTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return handlerError{user: "could not populate weather damage template", err: err}
+	}
+
+	return nil
+}
+
+// runWeather applies the end of turn effects of the battle's weather to both
+// trainers' active Pokemon, then counts down the turns left in the weather and
+// tells the trainers whether it continues or has cleared up.
+func (tp *turnProcessor) runWeather(ctx context.Context, public bool, b *pkmn.Battle, curr, opponent *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	if b.Weather == pkmn.NoWeather {
+		// There's no weather to speak of
+		return nil
+	}
+
+	err := tp.runWeatherDamage(ctx, public, b, curr)
+	if err != nil {
+		return err
+	}
+	err = tp.runWeatherDamage(ctx, public, b, opponent)
+	if err != nil {
+		return err
+	}
+
+	weather := b.Weather
+	ended := b.AdvanceWeather() != pkmn.NoWeather
+	err = sendWeatherReport(client, curr.lastContactURL, public, weather, false, ended)
+	if err != nil {
+		return handlerError{user: "could not populate weather report template", err: err}
+	}
+
+	return nil
+}
+
+// runSideConditions counts down the turns left in the conditions on the
+// trainer's side of the field and tells the trainers about any that ran out.
+func (tp *turnProcessor) runSideConditions(ctx context.Context, public bool, t *battleTrainerData, reportURL string) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	for _, sc := range t.battleInfo.GetTrainerBattleInfo().AdvanceSideConditions() {
+		err := sendSideConditionReport(client, reportURL, public, sc, t.trainer.GetTrainer(), false)
+		if err != nil {
+			return handlerError{user: "could not populate side condition report template", err: err}
+		}
+	}
+
+	return nil
+}
+
+// autoFillAction sets up the next action of a human trainer whose active
+// Pokemon has to keep using a move, and tells them about it. It returns true
+// if the action was filled in.
+func (tp *turnProcessor) autoFillAction(ctx context.Context, t *battleTrainerData) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	if t.trainer.GetTrainer().Type != pkmn.HumanTrainerType {
+		// Bots have their actions picked for them during preprocessing
+		return false, nil
+	}
+
+	moveID, forced := t.activePkmnBattleInfo().GetPokemonBattleInfo().ForcedMove()
+	if !forced {
+		return false, nil
+	}
+
+	move, err := loadMove(ctx, client, tp.Fetcher, moveID)
+	if err != nil {
+		return false, err
+	}
+
+	t.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
+	t.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
+		Type: pkmn.MoveBattleActionType,
+		Val:  moveID}
+
+	templInfo := struct {
+		PokemonName string
+		MoveName    string
+	}{
+		PokemonName: t.activePkmn().GetPokemon().DisplayName(),
+		MoveName:    move.Name}
+	err = messaging.SendTempl(client, t.lastContactURL, messaging.TemplMessage{
+		Templ:     moveAutoFilledTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return false, handlerError{user: "could not populate move auto-filled template", err: err}
+	}
+
+	return true, nil
+}
+
+// checkBattleOver checks if either trainer in the battle has lost, and if so,
+// ends the battle. It returns true if the battle is over.
+func (tp *turnProcessor) checkBattleOver(ctx context.Context, public bool, bd *battleData) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	// Make these values easier to access
+	curr := bd.requester
+	opponent := bd.opponent
+
+	// Check if the requester has lost
+	battleOver := false
+	var lostTrainer, wonTrainer *battleTrainerData
+	playerLost, err := tp.checkIfPlayerLost(ctx, bd, curr, opponent)
+	if err != nil {
+		return false, err
+	}
+	if playerLost {
+		lostTrainer = curr
+		wonTrainer = opponent
+		battleOver = true
+	}
+	// Check if the opponent has lost
+	playerLost, err = tp.checkIfPlayerLost(ctx, bd, opponent, curr)
+	if err != nil {
+		return false, err
+	}
+	if playerLost {
+		lostTrainer = opponent
+		wonTrainer = curr
+		battleOver = true
+	}
+
+	if battleOver {
+		// Put the trainers back in waiting mode
+		curr.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
+		opponent.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
+
+		// Send a notification that the battle is over
+		trainerLostTemplInfo := struct {
+			LostTrainer string
+			WonTrainer  string
+		}{
+			LostTrainer: lostTrainer.trainer.GetTrainer().Name,
+			WonTrainer:  wonTrainer.trainer.GetTrainer().Name}
+		err = messaging.SendTempl(client, curr.lastContactURL, messaging.TemplMessage{
+			Type:      messaging.Good,
+			Templ:     trainerLostTemplate,
+			TemplInfo: trainerLostTemplInfo,
+			Public:    public})
+		if err != nil {
+			return false, err
+		}
+
+		// Give out a badge if a human beat a gym leader
+		if bd.battle.GetBattle().GymBattle &&
+			wonTrainer.trainer.GetTrainer().Type == pkmn.HumanTrainerType &&
+			lostTrainer.trainer.GetTrainer().Type == pkmn.GymLeaderTrainerType {
+			err = tp.awardBadge(ctx, public, bd.battle.GetBattle().GymRegion, wonTrainer)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		// Humans earn prize money for beating other trainers
+		if wonTrainer.trainer.GetTrainer().Type == pkmn.HumanTrainerType {
+			err = tp.awardPrizeMoney(ctx, public, wonTrainer, lostTrainer)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		// Check all the requester's and opponent's Pokemon to see if they
+		// should level up.
+		_, err = levelUpPartyIfPossible(ctx, tp.Services, curr.basicTrainerData)
+		if err != nil {
+			return false, err
+		}
+		_, err = levelUpPartyIfPossible(ctx, tp.Services, opponent.basicTrainerData)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return battleOver, nil
+}
+
+// awardBadge gives the winner of a gym battle the badge of the gym leader they
+// beat in the given region and lets them know about it.
+func (tp *turnProcessor) awardBadge(ctx context.Context, public bool, region pkmn.Region, winner *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	leader, ok := pkmn.NextGymLeader(winner.trainer.GetTrainer(), region)
+	if !ok {
+		// The trainer already has every badge. This can only happen if they
+		// somehow fought two gym battles at once.
+		return nil
+	}
+	newAreas := winner.trainer.GetTrainer().AwardBadge(region)
+
+	templInfo := struct {
+		TrainerName string
+		LeaderName  string
+		Badge       string
+		Badges      int
+		TotalBadges int
+		Region      string
+		NewAreas    []string
+	}{
+		TrainerName: winner.trainer.GetTrainer().Name,
+		LeaderName:  leader.Name,
+		Badge:       leader.Badge,
+		Badges:      winner.trainer.GetTrainer().Badges(region),
+		TotalBadges: len(pkmn.GymLeaders(region)),
+		Region:      strings.Title(region.Name())}
+	for _, area := range newAreas {
+		templInfo.NewAreas = append(templInfo.NewAreas, area.Name)
+	}
+	err := messaging.SendTempl(client, winner.lastContactURL, messaging.TemplMessage{
+		Type:      messaging.Good,
+		Templ:     badgeEarnedTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// awardPrizeMoney gives the winner of a battle prize money for beating the
+// loser, records it in the winner's ledger and lets them know about it. The
+// prize depends on the level of the loser's strongest Pokemon. Human losers
+// pay the prize themselves, which is recorded in their ledger too.
+func (tp *turnProcessor) awardPrizeMoney(ctx context.Context, public bool, winner, loser *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	level := 0
+	for _, p := range loser.pkmn {
+		if p.GetPokemon().Level > level {
+			level = p.GetPokemon().Level
+		}
+	}
+	prize := pkmn.PrizeMoney(loser.trainer.GetTrainer(), level)
+	if prize <= 0 {
+		return nil // Wild Pokemon and broke trainers don't carry money
+	}
+
+	entry := winner.trainer.GetTrainer().Earn(prize, pkmn.PrizeLedgerReason,
+		"beat "+loser.trainer.GetTrainer().Name, tp.Clock.Now())
+	if entry.Amount <= 0 {
+		return nil // The winner can't carry any more money
+	}
+	err := tp.DB.SaveLedgerEntry(ctx, winner.trainer, tp.DB.NewLedgerEntry(entry))
+	if err != nil {
+		return handlerError{user: "could not record prize money", err: err}
+	}
+
+	// Human trainers pay exactly what the winner got
+	if loser.trainer.GetTrainer().Type == pkmn.HumanTrainerType {
+		lostEntry, err := loser.trainer.GetTrainer().Spend(entry.Amount, pkmn.PrizeLedgerReason,
+			"lost to "+winner.trainer.GetTrainer().Name, tp.Clock.Now())
+		if err != nil {
+			return handlerError{user: "could not take the prize money from the loser", err: err}
+		}
+		err = tp.DB.SaveLedgerEntry(ctx, loser.trainer, tp.DB.NewLedgerEntry(lostEntry))
+		if err != nil {
+			return handlerError{user: "could not record prize money", err: err}
+		}
+
+		templInfo := struct {
+			TrainerName string
+			WinnerName  string
+			Prize       int
+			Balance     int
+		}{
+			TrainerName: loser.trainer.GetTrainer().Name,
+			WinnerName:  winner.trainer.GetTrainer().Name,
+			Prize:       -lostEntry.Amount,
+			Balance:     lostEntry.Balance}
+		err = messaging.SendTempl(client, loser.lastContactURL, messaging.TemplMessage{
+			Templ:     prizeMoneyPaidTemplate,
+			TemplInfo: templInfo,
+			Public:    public})
+		if err != nil {
+			return err
+		}
+	}
+
+	templInfo := struct {
+		TrainerName string
+		Prize       int
+		Balance     int
+	}{
+		TrainerName: winner.trainer.GetTrainer().Name,
+		Prize:       entry.Amount,
+		Balance:     entry.Balance}
+	err = messaging.SendTempl(client, winner.lastContactURL, messaging.TemplMessage{
+		Type:      messaging.Good,
+		Templ:     prizeMoneyTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// replaceFainted sends out the Pokemon in the given party slot to replace the
+// user's fainted active Pokemon. This happens outside of the normal turn
+// order, so it doesn't use up the user's turn. It returns true if the battle
+// ended because of the replacement.
+func (tp *turnProcessor) replaceFainted(ctx context.Context, public bool, bd *battleData, user, target *battleTrainerData, slot int) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	user.battleInfo.GetTrainerBattleInfo().CurrPkmnSlot = slot
+	user.battleInfo.GetTrainerBattleInfo().MustReplace = false
+	target.trainer.GetTrainer().Pokedex.See(user.activePkmn().GetPokemon().ID)
+
+	templInfo := struct {
+		TrainerName string
+		PokemonName string
+		Level       int
+	}{
+		TrainerName: user.trainer.GetTrainer().Name,
+		PokemonName: user.activePkmn().GetPokemon().DisplayName(),
+		Level:       user.activePkmn().GetPokemon().Level}
+	err := messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
+		Public:    public,
+		Templ:     initialPokemonSendOutTemplate,
+		TemplInfo: templInfo})
+	if err != nil {
+		return false, handlerError{user: "could not populate initial Pokemon send out template", err: err}
+	}
+
+	// Apply the effects of entering the battle
+	err = tp.runEntry(ctx, public, bd.battle.GetBattle(), user, target)
+	if err != nil {
+		return false, err
+	}
+
+	if user.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
+		// The entry hazards made the replacement faint too, which may have
+		// ended the battle
+		battleOver, err := tp.checkBattleOver(ctx, public, bd)
+		if err != nil || battleOver {
+			return battleOver, err
+		}
+		return tp.promptReplacements(ctx, public, bd)
+	}
+
+	if user.trainer.GetTrainer().Type == pkmn.HumanTrainerType {
+		// Send the new action options to the user
+		err = makeActionOptions(ctx, tp.Services, bd.battle.GetBattle(), user.basicTrainerData, user.battleInfo)
+		if err != nil {
+			return false, handlerError{user: "could not send action options", err: err}
+		}
+	}
+
+	return false, nil
+}
+
+// promptReplacements finds the trainers whose active Pokemon have fainted and
+// puts them in the state of having to replace it. Human trainers are asked to
+// pick a replacement, while bots pick one right away. It returns true if the
+// battle ended because of a bot's replacement.
+func (tp *turnProcessor) promptReplacements(ctx context.Context, public bool, bd *battleData) (bool, error) {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	for _, pair := range [][2]*battleTrainerData{{bd.requester, bd.opponent}, {bd.opponent, bd.requester}} {
+		user, target := pair[0], pair[1]
+
+		if user.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP > 0 ||
+			user.battleInfo.GetTrainerBattleInfo().MustReplace {
+			// This trainer doesn't need a new replacement
+			continue
+		}
+
+		if user.trainer.GetTrainer().Type != pkmn.HumanTrainerType {
+			// Bots send out the first Pokemon in their party that can still
+			// fight
+			slot := -1
+			for i, pbi := range user.pkmnBattleInfo {
+				if pbi.GetPokemonBattleInfo().CurrHP > 0 {
+					slot = i
+					break
+				}
+			}
+			if slot == -1 {
+				// The bot has nothing left to send out, which is handled
+				// when checking if the battle is over
+				continue
+			}
+			battleOver, err := tp.replaceFainted(ctx, public, bd, user, target, slot)
+			if err != nil || battleOver {
+				return battleOver, err
+			}
+			continue
+		}
+
+		user.battleInfo.GetTrainerBattleInfo().MustReplace = true
+		err := messaging.SendTempl(client, user.lastContactURL, messaging.TemplMessage{
+			Templ:     mustReplaceTemplate,
+			TemplInfo: user.activePkmn().GetPokemon().DisplayName()})
+		if err != nil {
+			return false, handlerError{user: "could not populate must replace template", err: err}
+		}
+	}
+
+	return false, nil
+}
+
+// runTurn runs the turn of the user on the target in the given battle. It
+// returns true if the turn should continue.
 //
 // The given move object is only used as appropriate, so it's acceptable to
 // pass an empty move object if that trainer is not using a move.
-func (tp *turnProcessor) runTurn(ctx context.Context, public bool, user, target *battleTrainerData, move pkmn.Move) (bool, error) {
+func (tp *turnProcessor) runTurn(ctx context.Context, public bool, b *pkmn.Battle, user, target *battleTrainerData, move pkmn.Move) (bool, error) {
 	switch user.battleInfo.GetTrainerBattleInfo().NextBattleAction.Type {
 	case pkmn.MoveBattleActionType:
-		return tp.runMove(ctx, public, user, target, move)
+		return tp.runMove(ctx, public, b, user, target, move)
 	case pkmn.SwitchBattleActionType:
-		return true, tp.runSwitch(ctx, public, user)
+		return true, tp.runSwitch(ctx, public, b, user, target)
 	case pkmn.CatchBattleActionType:
-		return tp.runCatch(ctx, public, user, target)
+		return tp.runCatch(ctx, public, b, user, target)
+	case pkmn.ItemBattleActionType:
+		return true, tp.runItem(ctx, public, user)
 	default:
 		panic("unsupported battle action type")
 	}
@@ -307,6 +976,12 @@ func (tp *turnProcessor) doesCurrTrainerGoFirst(ctx context.Context, bd *battleD
 		return true
 	}
 
+	// Items only affect the user's own Pokemon, so the order is
+	// inconsequential here as well.
+	if currAction == pkmn.ItemBattleActionType && opponentAction == pkmn.ItemBattleActionType {
+		return true
+	}
+
 	// When two trainers are using a move, we must consult the nature of the
 	// moves and the Pokemon themselves to see who goes first.
 	if currAction == pkmn.MoveBattleActionType && opponentAction == pkmn.MoveBattleActionType {
@@ -314,7 +989,9 @@ func (tp *turnProcessor) doesCurrTrainerGoFirst(ctx context.Context, bd *battleD
 			*bd.opponent.activePkmn().GetPokemon(),
 			*bd.requester.activePkmnBattleInfo().GetPokemonBattleInfo(),
 			*bd.opponent.activePkmnBattleInfo().GetPokemonBattleInfo(),
-			currMove, opponentMove)
+			*bd.requester.battleInfo.GetTrainerBattleInfo(),
+			*bd.opponent.battleInfo.GetTrainerBattleInfo(),
+			currMove, opponentMove, bd.battle.GetBattle().Weather)
 
 		// Return true if goesFirst is 1, meaning that the first trainer we
 		// gave CalcMoveOrder goes first, which is the current trainer.
@@ -324,6 +1001,71 @@ func (tp *turnProcessor) doesCurrTrainerGoFirst(ctx context.Context, bd *battleD
 	panic("unsupported combination of battle actions")
 }
 
+// recordFacing records that the two trainers' active Pokemon have been in
+// battle against each other.
+func recordFacing(t1, t2 *battleTrainerData) {
+	pkmn.Face(t1.activePkmn().GetPokemon(), t2.activePkmn().GetPokemon(),
+		t1.activePkmnBattleInfo().GetPokemonBattleInfo(), t2.activePkmnBattleInfo().GetPokemonBattleInfo())
+}
+
+// awardExperience shares the experience and effort values for defeating the
+// loser's active Pokemon between the winner's Pokemon that fought it or are
+// holding an Exp. Share, then lets the winner know what each one earned. Only
+// human trainers' Pokemon gain experience.
+func (tp *turnProcessor) awardExperience(ctx context.Context, public bool, winner, loser *battleTrainerData) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+
+	if winner.trainer.GetTrainer().Type != pkmn.HumanTrainerType {
+		return nil
+	}
+
+	fainted := *loser.activePkmn().GetPokemon()
+	wild := loser.trainer.GetTrainer().Type == pkmn.WildTrainerType
+
+	party := make([]pkmn.Pokemon, len(winner.pkmn))
+	partyBIs := make([]pkmn.PokemonBattleInfo, len(winner.pkmnBattleInfo))
+	for i := range winner.pkmn {
+		party[i] = *winner.pkmn[i].GetPokemon()
+		partyBIs[i] = *winner.pkmnBattleInfo[i].GetPokemonBattleInfo()
+	}
+	exps := pkmn.ShareExperience(fainted, *loser.activePkmnBattleInfo().GetPokemonBattleInfo(), wild, party, partyBIs)
+
+	type expGain struct {
+		PokemonName string
+		Experience  int
+	}
+	templInfo := struct {
+		FaintedName string
+		Gains       []expGain
+	}{
+		FaintedName: fainted.DisplayName()}
+	for i, exp := range exps {
+		if exp <= 0 {
+			continue
+		}
+		p := winner.pkmn[i].GetPokemon()
+		p.Experience += exp
+		tp.Log.Infof(ctx, "awarding %v %v experience points", p.Name, exp)
+		evs := p.GainEVs(fainted)
+		tp.Log.Infof(ctx, "awarding %v %v effort values", p.Name, evs)
+		templInfo.Gains = append(templInfo.Gains, expGain{PokemonName: p.DisplayName(), Experience: exp})
+	}
+
+	if len(templInfo.Gains) == 0 {
+		return nil
+	}
+	err := messaging.SendTempl(client, winner.lastContactURL, messaging.TemplMessage{
+		Templ:     experienceGainedTemplate,
+		TemplInfo: templInfo,
+		Public:    public})
+	if err != nil {
+		return handlerError{user: "could not populate experience gained template", err: err}
+	}
+
+	return nil
+}
+
 // process runs all required steps for a single battle turn.
 //
 // If the first return value is true, then the battle has ended as a result of
@@ -357,6 +1099,10 @@ func (tp *turnProcessor) process(ctx context.Context, bd *battleData) (bool, err
 		}
 	}
 
+	// The active Pokemon at the start of the turn are about to fight each
+	// other
+	recordFacing(curr, opponent)
+
 	// Decides whether messages should be seen publicly or not. Messages should
 	// only be seen publicly if two human trainers are involved. If the
 	// requester is a bot, then nobody is concerned about what's going on in
@@ -373,94 +1119,80 @@ func (tp *turnProcessor) process(ctx context.Context, bd *battleData) (bool, err
 	// Run the trainers' turns in an order depending on who goes first.
 	currGoesFirst := tp.doesCurrTrainerGoFirst(ctx, bd, currPkmnMove, opponentPkmnMove)
 	if currGoesFirst {
-		runNextAction, err := tp.runTurn(ctx, public, curr, opponent, currPkmnMove)
+		runNextAction, err := tp.runTurn(ctx, public, bd.battle.GetBattle(), curr, opponent, currPkmnMove)
 		if err != nil {
 			return false, err
 		}
 		if runNextAction {
-			_, err = tp.runTurn(ctx, public, opponent, curr, opponentPkmnMove)
+			_, err = tp.runTurn(ctx, public, bd.battle.GetBattle(), opponent, curr, opponentPkmnMove)
 		}
 	} else {
-		runNextAction, err := tp.runTurn(ctx, public, opponent, curr, opponentPkmnMove)
+		runNextAction, err := tp.runTurn(ctx, public, bd.battle.GetBattle(), opponent, curr, opponentPkmnMove)
 		if err != nil {
 			return false, err
 		}
 		if runNextAction {
-			_, err = tp.runTurn(ctx, public, curr, opponent, currPkmnMove)
+			_, err = tp.runTurn(ctx, public, bd.battle.GetBattle(), curr, opponent, currPkmnMove)
 			if err != nil {
 				return false, err
 			}
 		}
 	}
 
-	// Check if the opponent Pokemon fainted and award the requester's Pokemon
-	// experience if so
-	if opponent.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
-		wild := opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType
-		exp := pkmn.Experience(*opponent.activePkmn().GetPokemon(), wild)
-		curr.activePkmn().GetPokemon().Experience += exp
-		tp.Log.Infof(ctx, "awarding %v %v experience points", curr.activePkmn().GetPokemon().Name, exp)
+	// Apply the end of turn effects of both active Pokemon's abilities
+	err = tp.runEndOfTurn(ctx, public, curr, opponent)
+	if err != nil {
+		return false, err
 	}
-	// Check if the requester Pokemon fainted and award the opponent's Pokemon
-	// experience if so
-	if curr.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
-		// We can always assume the requester is not a wild Pokemon trainer
-		exp := pkmn.Experience(*curr.activePkmn().GetPokemon(), false)
-		opponent.activePkmn().GetPokemon().Experience += exp
-		tp.Log.Infof(ctx, "awarding %v %v experience points", opponent.activePkmn().GetPokemon().Name, exp)
+	err = tp.runEndOfTurn(ctx, public, opponent, curr)
+	if err != nil {
+		return false, err
 	}
 
-	// Check if the requester has lost
-	battleOver := false
-	var lostTrainerName, wonTrainerName string
-	playerLost, err := tp.checkIfPlayerLost(ctx, bd, curr, opponent)
+	// Apply the effects of the weather and count down its remaining turns
+	err = tp.runWeather(ctx, public, bd.battle.GetBattle(), curr, opponent)
 	if err != nil {
 		return false, err
 	}
-	if playerLost {
-		lostTrainerName = curr.trainer.GetTrainer().Name
-		wonTrainerName = opponent.trainer.GetTrainer().Name
-		battleOver = true
-	}
-	// Check if the opponent has lost
-	playerLost, err = tp.checkIfPlayerLost(ctx, bd, opponent, curr)
+
+	// Count down the remaining turns of both sides' conditions
+	err = tp.runSideConditions(ctx, public, curr, curr.lastContactURL)
 	if err != nil {
 		return false, err
 	}
-	if playerLost {
-		lostTrainerName = opponent.trainer.GetTrainer().Name
-		wonTrainerName = curr.trainer.GetTrainer().Name
-		battleOver = true
+	err = tp.runSideConditions(ctx, public, opponent, curr.lastContactURL)
+	if err != nil {
+		return false, err
 	}
 
-	if battleOver {
-		// Put the trainers back in waiting mode
-		curr.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
-		opponent.trainer.GetTrainer().Mode = pkmn.WaitingTrainerMode
+	// The active Pokemon that made it to the end of the turn fought each other
+	recordFacing(curr, opponent)
 
-		// Send a notification that the battle is over
-		trainerLostTemplInfo := struct {
-			LostTrainer string
-			WonTrainer  string
-		}{
-			LostTrainer: lostTrainerName,
-			WonTrainer:  wonTrainerName}
-		err = messaging.SendTempl(client, curr.lastContactURL, messaging.TemplMessage{
-			Type:      messaging.Good,
-			Templ:     trainerLostTemplate,
-			TemplInfo: trainerLostTemplInfo,
-			Public:    public})
+	// Check if either active Pokemon fainted and award the Pokemon that
+	// fought it experience and effort values if so
+	if opponent.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
+		err = tp.awardExperience(ctx, public, curr, opponent)
 		if err != nil {
 			return false, err
 		}
-
-		// Check all the requester's and opponent's Pokemon to see if they
-		// should level up.
-		_, err = levelUpPartyIfPossible(ctx, tp.Services, curr.basicTrainerData)
+	}
+	if curr.activePkmnBattleInfo().GetPokemonBattleInfo().CurrHP <= 0 {
+		err = tp.awardExperience(ctx, public, opponent, curr)
 		if err != nil {
 			return false, err
 		}
-		_, err = levelUpPartyIfPossible(ctx, tp.Services, opponent.basicTrainerData)
+	}
+
+	// Check if either trainer has lost
+	battleOver, err := tp.checkBattleOver(ctx, public, bd)
+	if err != nil {
+		return false, err
+	}
+
+	if !battleOver {
+		// Trainers whose active Pokemon fainted have to replace it before
+		// the next turn
+		battleOver, err = tp.promptReplacements(ctx, public, bd)
 		if err != nil {
 			return false, err
 		}
@@ -468,6 +1200,35 @@ func (tp *turnProcessor) process(ctx context.Context, bd *battleData) (bool, err
 
 	curr.battleInfo.GetTrainerBattleInfo().FinishedTurn = false
 	opponent.battleInfo.GetTrainerBattleInfo().FinishedTurn = false
+	curr.activePkmnBattleInfo().GetPokemonBattleInfo().EndTurn()
+	opponent.activePkmnBattleInfo().GetPokemonBattleInfo().EndTurn()
+	bd.battle.GetBattle().Turn++
+
+	if !battleOver {
+		// Trainers whose Pokemon have to keep using a move don't get to pick
+		// their next action
+		currFilled, err := tp.autoFillAction(ctx, curr)
+		if err != nil {
+			return false, err
+		}
+		_, err = tp.autoFillAction(ctx, opponent)
+		if err != nil {
+			return false, err
+		}
+
+		// Nobody else is going to start the next turn if the current
+		// trainer's action was filled in, so process it right away if the
+		// opponent is ready
+		if currFilled {
+			ready, err := preprocessTurn(ctx, tp.Services, bd)
+			if err != nil {
+				return false, err
+			}
+			if ready {
+				return tp.process(ctx, bd)
+			}
+		}
+	}
 
 	return battleOver, nil
 }
@@ -3,11 +3,25 @@ package handlers
 import "github.com/velovix/snoreslacks/database"
 
 // givePokemon adds a Pokemon to the given party, or returns false if the
-// player already has the maximum amount of Pokemon.
+// player already has the maximum amount of Pokemon. The Pokemon is put in the
+// last slot of the party.
 func givePokemon(party []database.Pokemon, pkmn database.Pokemon) ([]database.Pokemon, bool) {
 	if len(party) >= 6 {
 		return party, false
 	}
 
+	pkmn.GetPokemon().Slot = len(party)
 	return append(party, pkmn), true
 }
+
+// leadSlot returns the slot of the first Pokemon in the party that hasn't
+// fainted, which is the Pokemon the trainer sends out first in a battle. False
+// is returned if every Pokemon in the party has fainted.
+func leadSlot(party []database.Pokemon) (int, bool) {
+	for slot, p := range party {
+		if !p.GetPokemon().Fainted() {
+			return slot, true
+		}
+	}
+	return 0, false
+}
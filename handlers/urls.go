@@ -20,4 +20,31 @@ const (
 	ForgetMoveHelpURL    = workerPrefix + "/forget-move-help"
 	NoForgetMoveURL      = workerPrefix + "/no-forget-move"
 	ForgetMoveURL        = workerPrefix + "/forget-move"
+	ViewBagURL           = workerPrefix + "/view-bag"
+	UseItemURL           = workerPrefix + "/use-item"
+	GiveItemURL          = workerPrefix + "/give-item"
+	TakeItemURL          = workerPrefix + "/take-item"
+	GymBattleURL         = workerPrefix + "/gym"
+	EvolvingHelpURL      = workerPrefix + "/evolving-help"
+	EvolveURL            = workerPrefix + "/evolve"
+	CancelEvolutionURL   = workerPrefix + "/cancel-evolution"
+	UseItemOOBURL        = workerPrefix + "/use-item-oob"
+	WalkURL              = workerPrefix + "/walk"
+	HealURL              = workerPrefix + "/heal"
+	ViewDexURL           = workerPrefix + "/view-dex"
+	TravelURL            = workerPrefix + "/travel"
+	ViewBoxURL           = workerPrefix + "/view-box"
+	DepositURL           = workerPrefix + "/deposit"
+	WithdrawURL          = workerPrefix + "/withdraw"
+	SwapPartyURL         = workerPrefix + "/swap-party"
+	NicknameURL          = workerPrefix + "/nickname"
+	ReleaseURL           = workerPrefix + "/release"
+	ReleasingHelpURL     = workerPrefix + "/releasing-help"
+	ConfirmReleaseURL    = workerPrefix + "/confirm-release"
+	CancelReleaseURL     = workerPrefix + "/cancel-release"
+	TradeURL             = workerPrefix + "/trade"
+	ConfirmTradeURL      = workerPrefix + "/confirm-trade"
+	CancelTradeURL       = workerPrefix + "/cancel-trade"
+	ViewMartURL          = workerPrefix + "/view-mart"
+	BuyItemURL           = workerPrefix + "/buy-item"
 )
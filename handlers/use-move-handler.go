@@ -42,6 +42,24 @@ func (h *UseMove) runTask(ctx context.Context, s Services) error {
 		return handlerError{user: "could not load battle data", err: errors.New("incomplete battle data object")}
 	}
 
+	// Pokemon that have to keep using a move can't do anything else
+	forced, err := sendIfMoveForced(ctx, s, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a forced move", err: err}
+	}
+	if forced {
+		return nil // There is nothing else to do
+	}
+
+	// A fainted Pokemon has to be replaced before anything else can be done
+	mustReplace, err := sendIfMustReplace(ctx, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a fainted Pokemon", err: err}
+	}
+	if mustReplace {
+		return nil // There is nothing else to do
+	}
+
 	// Check if the command looks correct
 	if len(slackReq.CommandParams) != 1 {
 		return sendInvalidCommand(client, requester.lastContactURL)
@@ -67,14 +85,31 @@ func (h *UseMove) runTask(ctx context.Context, s Services) error {
 	// Get the move ID of the requested slot
 	moveID := battleData.requester.activePkmn().GetPokemon().MoveIDsAsSlice()[moveSlotID-1]
 
-	// Set up the next action to be a move action
-	battleData.requester.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
-	battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
-		Type: pkmn.MoveBattleActionType,
-		Val:  moveID}
+	// Check that the Pokemon isn't locked into a different move by its
+	// Choice Band
+	lockedMoveID := battleData.requester.activePkmnBattleInfo().GetPokemonBattleInfo().ChoiceLockedMove
+	if lockedMoveID != 0 && lockedMoveID != moveID {
+		lockedMove, err := loadMove(ctx, client, s.Fetcher, lockedMoveID)
+		if err != nil {
+			return handlerError{user: "could not fetch move information", err: err}
+		}
+		templInfo := struct {
+			PokemonName string
+			MoveName    string
+		}{
+			PokemonName: battleData.requester.activePkmn().GetPokemon().DisplayName(),
+			MoveName:    lockedMove.Name}
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     choiceLockedTemplate,
+			TemplInfo: templInfo})
+		if err != nil {
+			return handlerError{user: "could not populate choice locked template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
 
 	// Get the move information
-	apiMove, err := s.Fetcher.FetchMove(ctx, client, battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction.Val)
+	apiMove, err := s.Fetcher.FetchMove(ctx, client, moveID)
 	if err != nil {
 		return handlerError{user: "could not fetch move information", err: err}
 	}
@@ -83,6 +118,41 @@ func (h *UseMove) runTask(ctx context.Context, s Services) error {
 		return handlerError{user: "could not fetch move information", err: err}
 	}
 
+	// Moves can't be used once they're out of PP. A Pokemon that's out of PP
+	// for every move it's allowed to use has to struggle instead.
+	p := battleData.requester.activePkmn().GetPokemon()
+	if !p.HasPP(move) {
+		usable, err := loadUsableMoves(ctx, client, s.Fetcher, p)
+		if err != nil {
+			return handlerError{user: "could not fetch move information", err: err}
+		}
+		if lockedMoveID == 0 && usable[0].ID != pkmn.StruggleMoveID {
+			templInfo := struct {
+				PokemonName string
+				MoveName    string
+			}{
+				PokemonName: p.DisplayName(),
+				MoveName:    move.Name}
+			err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+				Templ:     noPPLeftTemplate,
+				TemplInfo: templInfo})
+			if err != nil {
+				return handlerError{user: "could not populate no PP left template", err: err}
+			}
+			return nil // There is nothing else to do
+		}
+		move, err = loadMove(ctx, client, s.Fetcher, pkmn.StruggleMoveID)
+		if err != nil {
+			return handlerError{user: "could not fetch move information", err: err}
+		}
+	}
+
+	// Set up the next action to be a move action
+	battleData.requester.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
+	battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
+		Type: pkmn.MoveBattleActionType,
+		Val:  move.ID}
+
 	// Send confirmation that the move was received
 	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
 		Templ:     moveConfirmationTemplate,
@@ -114,13 +184,14 @@ func (h *UseMove) runTask(ctx context.Context, s Services) error {
 	if err != nil {
 		return handlerError{user: "could not save battle session", err: err}
 	}
-	if battleOver && battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType {
+	if battleOver && (battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType ||
+		battleData.opponent.trainer.GetTrainer().Type == pkmn.GymLeaderTrainerType) {
 		// The battle is over and the trainer is one-time-use. It's time to
 		// destroy him.
 		s.Log.Infof(ctx, "deleting temporary trainer %v because the battle is over", battleData.opponent.trainer.GetTrainer().UUID)
 		err = s.DB.PurgeTrainer(ctx, battleData.opponent.trainer.GetTrainer().UUID)
 		if err != nil {
-			return handlerError{user: "could not purge the wild trainer", err: err}
+			return handlerError{user: "could not purge the temporary trainer", err: err}
 		}
 	}
 	if battleOver {
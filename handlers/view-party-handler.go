@@ -28,6 +28,30 @@ func partyInfoAilmentText(ailment pkmn.Ailment) string {
 	}
 }
 
+// partyInfoIVText returns text summarizing the overall quality of a Pokemon's
+// individual values, in the style of the IV judge from the main series games.
+func partyInfoIVText(ivTotal int) string {
+	switch {
+	case ivTotal <= 90:
+		return "decent"
+	case ivTotal <= 120:
+		return "above average"
+	case ivTotal <= 150:
+		return "relatively superior"
+	default:
+		return "outstanding"
+	}
+}
+
+// partyInfoHeldItemText returns the name of the given held item in a format for
+// the party viewer.
+func partyInfoHeldItemText(item pkmn.Item) string {
+	if item == pkmn.NoItem {
+		return "none"
+	}
+	return item.Name()
+}
+
 // ViewParty manages requests to print the trainer's full party.
 type ViewParty struct {
 	Services
@@ -38,18 +62,27 @@ type ViewParty struct {
 // object.
 func (h *ViewParty) makeSinglePartyEntry(p *pkmn.Pokemon, currHP int, statusCondition string) viewSinglePokemonTemplateInfo {
 	return viewSinglePokemonTemplateInfo{
-		Name:  p.Name,
+		Name:  p.DisplayName(),
 		ID:    p.ID,
+		Form:  p.Form,
+		Shiny: p.Shiny,
 		Level: p.Level,
 		Type1: p.Type1,
 		Type2: p.Type2,
 
+		Nature:    p.Nature.Name(),
+		Ability:   p.Ability,
+		HeldItem:  partyInfoHeldItemText(p.HeldItem),
+		IVQuality: partyInfoIVText(p.IVTotal()),
+
+		OriginalTrainer: p.OriginalTrainerName,
+
 		HP:        pkmn.CalcOOBHP(p.HP, *p),
-		Attack:    pkmn.CalcOOBStat(p.Attack, *p),
-		Defense:   pkmn.CalcOOBStat(p.Defense, *p),
-		SpAttack:  pkmn.CalcOOBStat(p.SpAttack, *p),
-		SpDefense: pkmn.CalcOOBStat(p.SpDefense, *p),
-		Speed:     pkmn.CalcOOBStat(p.Speed, *p),
+		Attack:    pkmn.CalcOOBStat(*p, pkmn.AttackStatType),
+		Defense:   pkmn.CalcOOBStat(*p, pkmn.DefenseStatType),
+		SpAttack:  pkmn.CalcOOBStat(*p, pkmn.SpecialAttackStatType),
+		SpDefense: pkmn.CalcOOBStat(*p, pkmn.SpecialDefenseStatType),
+		Speed:     pkmn.CalcOOBStat(*p, pkmn.SpeedStatType),
 
 		CurrHP:          currHP,
 		StatusCondition: statusCondition}
@@ -86,6 +119,10 @@ func (h *ViewParty) runTask(ctx context.Context, s Services) error {
 
 			statusCondition = partyInfoAilmentText(inBattleStats.GetPokemonBattleInfo().Ailment)
 			currHP = inBattleStats.GetPokemonBattleInfo().CurrHP
+		} else {
+			// Pokemon keep their HP and status between battles
+			statusCondition = partyInfoAilmentText(p.Ailment)
+			currHP = p.CurrHP()
 		}
 
 		// Add a new entry to the party list
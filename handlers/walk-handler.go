@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+)
+
+// Walk handles requests to take the party for a walk, which makes every
+// Pokemon in it a bit friendlier.
+type Walk struct {
+	Services
+}
+
+func (h *Walk) runTask(ctx context.Context, s Services) error {
+	// Load request-specific objects
+	client := ctx.Value("client").(messaging.Client)
+	requester := ctx.Value("requesting trainer").(*basicTrainerData)
+
+	t := requester.trainer.GetTrainer()
+
+	// Trainers can only go on a walk every so often
+	if !t.CanWalk(s.Clock) {
+		minutes := int(t.NextWalk(s.Clock).Minutes()) + 1
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     tooSoonToWalkTemplate,
+			TemplInfo: minutes})
+		if err != nil {
+			return handlerError{user: "could not populate too soon to walk template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
+	// Go on the walk
+	t.LastWalk = s.Clock.Now()
+	for _, p := range requester.pkmn {
+		p.GetPokemon().RaiseFriendship(pkmn.WalkFriendship)
+	}
+
+	err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+		Templ:     walkedTemplate,
+		TemplInfo: nil})
+	if err != nil {
+		return handlerError{user: "could not populate walked template", err: err}
+	}
+
+	// Save the trainer and their party
+	err = saveBasicTrainerData(ctx, s.DB, requester)
+	if err != nil {
+		return handlerError{user: "could not save trainer data", err: err}
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ type WildEncounter struct {
 
 func (h *WildEncounter) runTask(ctx context.Context, s Services) error {
 	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
 	client := ctx.Value("client").(messaging.Client)
 	requester := ctx.Value("requesting trainer").(*basicTrainerData)
 
@@ -32,21 +33,42 @@ func (h *WildEncounter) runTask(ctx context.Context, s Services) error {
 		}
 	}
 
+	// Pokemon that have fainted can't battle until they're healed
+	lead, ok := leadSlot(requester.pkmn)
+	if !ok {
+		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     allFaintedTemplate,
+			TemplInfo: slackReq.SlashCommand})
+		if err != nil {
+			return handlerError{user: "could not populate all fainted template", err: err}
+		}
+		return nil // There is nothing else to do
+	}
+
 	// Put the trainer in battle mode
 	requester.trainer.GetTrainer().Mode = pkmn.BattlingTrainerMode
 
-	// Randomly decide what wild Pokemon the trainer will encounter
-	wildEntry := pkmn.RandomWildPokemon(pkmn.AvailableWildPokemon(*requester.trainer.GetTrainer()))
-	// Get PokeAPI Data on the Pokemon
-	apiPkmn, err := s.Fetcher.FetchPokemon(ctx, client, wildEntry.ID)
+	// Randomly decide what wild Pokemon the trainer will encounter in the
+	// area they're in at this time of day
+	_, area := requester.trainer.GetTrainer().CurrentArea()
+	wildEntry, level := pkmn.RandomWildPokemon(s.RNG, area, pkmn.TimeOfDayAt(s.Clock.Now()))
+	// Get PokeAPI Data on the Pokemon, which may be a regional variant
+	apiPkmn, err := pokeapi.RandomWildVariety(ctx, client, s.Fetcher, s.RNG, wildEntry.ID)
 	if err != nil {
 		return handlerError{user: "unable to fetch Pokemon information", err: err}
 	}
 	// Create the pkmn.Pokemon from the PokeAPI data
-	pokeWild, err := pokeapi.NewPokemon(ctx, client, s.Fetcher, apiPkmn, wildEntry.MedianLevel)
+	pokeWild, err := pokeapi.NewPokemon(ctx, client, s.Fetcher, apiPkmn, level)
 	if err != nil {
 		return handlerError{user: "unable to fetch Pokemon information", err: err}
 	}
+	// Some species come in several forms that only differ in looks, and
+	// every so often a wild Pokemon is shiny
+	err = pokeapi.RandomizeForm(ctx, client, s.Fetcher, s.RNG, apiPkmn, &pokeWild)
+	if err != nil {
+		return handlerError{user: "unable to fetch Pokemon form information", err: err}
+	}
+	pokeWild.Shiny = pkmn.RollShiny(s.RNG)
 	// Wrap the new Pokemon in a database object
 	wild := s.DB.NewPokemon(pokeWild)
 
@@ -63,7 +85,9 @@ func (h *WildEncounter) runTask(ctx context.Context, s Services) error {
 		P2:   wildTrainer.GetTrainer().UUID,
 		Mode: pkmn.StartedBattleMode})
 	// Create trainer battle info
-	trainerBattleInfo := s.DB.NewTrainerBattleInfo(pkmn.TrainerBattleInfo{TrainerUUID: requester.trainer.GetTrainer().UUID})
+	trainerBattleInfo := s.DB.NewTrainerBattleInfo(pkmn.TrainerBattleInfo{
+		TrainerUUID:  requester.trainer.GetTrainer().UUID,
+		CurrPkmnSlot: lead})
 	wildBattleInfo := s.DB.NewTrainerBattleInfo(pkmn.TrainerBattleInfo{TrainerUUID: wildTrainer.GetTrainer().UUID})
 
 	s.Log.Infof(ctx, "creating a new battle: %+v", b)
@@ -71,33 +95,54 @@ func (h *WildEncounter) runTask(ctx context.Context, s Services) error {
 	// Create Pokemon battle info for all the trainer's Pokemon and the wild Pokemon
 	pkmnBIs := make([]database.PokemonBattleInfo, 0, 7)
 	pkmnBIs = append(pkmnBIs, s.DB.NewPokemonBattleInfo( // Add the wild Pokemon battle info
-		pkmn.PokemonBattleInfo{
-			PkmnUUID: wild.GetPokemon().UUID,
-			CurrHP:   pkmn.CalcOOBHP(wild.GetPokemon().HP, *wild.GetPokemon())}))
-	// Add the trainer's Pokemon battle info
+		wild.GetPokemon().NewBattleInfo()))
+	// Add the trainer's Pokemon battle info. Their Pokemon start the battle
+	// in whatever condition the last one left them in.
 	for _, p := range requester.pkmn {
-		pkmnBIs = append(pkmnBIs, s.DB.NewPokemonBattleInfo(pkmn.PokemonBattleInfo{
-			PkmnUUID: p.GetPokemon().UUID,
-			CurrHP:   pkmn.CalcOOBHP(p.GetPokemon().HP, *p.GetPokemon())}))
+		pkmnBIs = append(pkmnBIs, s.DB.NewPokemonBattleInfo(p.GetPokemon().NewBattleInfo()))
 	}
 
+	requester.trainer.GetTrainer().Pokedex.See(wild.GetPokemon().ID)
+
 	// Send message telling the trainer that an encounter happened
 	templInfo := struct {
 		WildPokemonName string
 		Level           int
+		Form            string
+		Shiny           bool
 	}{
 		WildPokemonName: wild.GetPokemon().Name,
-		Level:           wild.GetPokemon().Level}
+		Level:           wild.GetPokemon().Level,
+		Form:            wild.GetPokemon().Form,
+		Shiny:           wild.GetPokemon().Shiny}
 	err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
 		Templ:     wildBattleStartedTemplate,
 		TemplInfo: templInfo,
-		Image:     wild.GetPokemon().SpriteURL})
+		Image:     wild.GetPokemon().Sprite()})
 	if err != nil {
 		return handlerError{user: "could not populate wild battle started template", err: err}
 	}
 
+	// Apply the switch-in effects of both leading Pokemon's abilities. The
+	// trainer's Pokemon battle info comes right after the wild Pokemon's.
+	leadPkmn := requester.pkmn[lead].GetPokemon()
+	leadPkmnBI := pkmnBIs[1+lead].GetPokemonBattleInfo()
+	wildPkmnBI := pkmnBIs[0].GetPokemonBattleInfo()
+	ar := pkmn.RunSwitchInAbility(b.GetBattle(), leadPkmn, wild.GetPokemon(), leadPkmnBI, wildPkmnBI)
+	err = sendAbilityReport(client, requester.lastContactURL, false, ar,
+		requester.trainer.GetTrainer(), leadPkmn, wildTrainer.GetTrainer(), wild.GetPokemon())
+	if err != nil {
+		return handlerError{user: "could not populate ability report template", err: err}
+	}
+	ar = pkmn.RunSwitchInAbility(b.GetBattle(), wild.GetPokemon(), leadPkmn, wildPkmnBI, leadPkmnBI)
+	err = sendAbilityReport(client, requester.lastContactURL, false, ar,
+		wildTrainer.GetTrainer(), wild.GetPokemon(), requester.trainer.GetTrainer(), leadPkmn)
+	if err != nil {
+		return handlerError{user: "could not populate ability report template", err: err}
+	}
+
 	// Send the trainer their action options
-	err = makeActionOptions(ctx, s, requester, trainerBattleInfo)
+	err = makeActionOptions(ctx, s, b.GetBattle(), requester, trainerBattleInfo)
 	if err != nil {
 		return handlerError{user: "could not send action options", err: err}
 	}
@@ -144,6 +189,7 @@ type CatchPokemon struct {
 
 func (h *CatchPokemon) runTask(ctx context.Context, s Services) error {
 	// Load request-specific objects
+	slackReq := ctx.Value("slack request").(messaging.SlackRequest)
 	client := ctx.Value("client").(messaging.Client)
 	requester := ctx.Value("requesting trainer").(*basicTrainerData)
 	battleData := ctx.Value("battle data").(*battleData)
@@ -163,6 +209,24 @@ func (h *CatchPokemon) runTask(ctx context.Context, s Services) error {
 		return handlerError{user: "could not load battle data", err: errors.New("incomplete battle data object")}
 	}
 
+	// Pokemon that have to keep using a move can't do anything else
+	forced, err := sendIfMoveForced(ctx, s, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a forced move", err: err}
+	}
+	if forced {
+		return nil // There is nothing else to do
+	}
+
+	// A fainted Pokemon has to be replaced before anything else can be done
+	mustReplace, err := sendIfMustReplace(ctx, battleData.requester)
+	if err != nil {
+		return handlerError{user: "could not check for a fainted Pokemon", err: err}
+	}
+	if mustReplace {
+		return nil // There is nothing else to do
+	}
+
 	if battleData.opponent.trainer.GetTrainer().Type != pkmn.WildTrainerType {
 		// You can only catch wild Pokemon! Let the user know that fact.
 		err := messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
@@ -175,11 +239,35 @@ func (h *CatchPokemon) runTask(ctx context.Context, s Services) error {
 		return nil // There's nothing else to do
 	}
 
+	// Check if the command looks correct
+	if len(slackReq.CommandParams) > 1 {
+		return sendInvalidCommand(client, requester.lastContactURL)
+	}
+
+	// Find the ball to throw, defaulting to a regular Poke Ball
+	ballName := pkmn.PokeBallItem.Name()
+	if len(slackReq.CommandParams) == 1 {
+		ballName = slackReq.CommandParams[0]
+	}
+	ball, ok, err := findBagItem(client, requester.lastContactURL, battleData.requester.trainer.GetTrainer(), ballName)
+	if err != nil || !ok {
+		return err
+	}
+	if ball.Category() != pkmn.BallItemCategory {
+		err = messaging.SendTempl(client, requester.lastContactURL, messaging.TemplMessage{
+			Templ:     notABallTemplate,
+			TemplInfo: ball.Name()})
+		if err != nil {
+			return handlerError{user: "could not populate not a ball template", err: err}
+		}
+		return nil // There's nothing else to do
+	}
+
 	// Set up the next action as a catch action
 	battleData.requester.battleInfo.GetTrainerBattleInfo().FinishedTurn = true
 	battleData.requester.battleInfo.GetTrainerBattleInfo().NextBattleAction = pkmn.BattleAction{
 		Type: pkmn.CatchBattleActionType,
-		Val:  0} // The val parameter has no meaning for catches
+		Val:  int(ball)}
 
 	// Get a turn processor ready to do any required processing
 	tp := turnProcessor{Services: s}
@@ -204,12 +292,13 @@ func (h *CatchPokemon) runTask(ctx context.Context, s Services) error {
 	if err != nil {
 		return handlerError{user: "could not save battle session", err: err}
 	}
-	if battleOver && battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType {
+	if battleOver && (battleData.opponent.trainer.GetTrainer().Type == pkmn.WildTrainerType ||
+		battleData.opponent.trainer.GetTrainer().Type == pkmn.GymLeaderTrainerType) {
 		// The battle is over and the trainer is one-time-use. It's time to
 		// destroy him.
 		err = s.DB.PurgeTrainer(ctx, battleData.opponent.trainer.GetTrainer().UUID)
 		if err != nil {
-			return handlerError{user: "could not purge the wild trainer", err: err}
+			return handlerError{user: "could not purge the temporary trainer", err: err}
 		}
 	}
 	if battleOver {
@@ -0,0 +1,234 @@
+package pkmn
+
+import "math/rand"
+
+// AbilityReport describes what happened when a Pokemon's ability activated.
+type AbilityReport struct {
+	// Activated is true if the ability had any effect. If it is false, the
+	// rest of the report should be ignored.
+	Activated bool
+	// Ability is the name of the ability that activated.
+	Ability string
+
+	OpponentAttStageChange int
+	OpponentParalyzed      bool
+	HolderSpeedStageChange int
+	// Endured is true if the ability let the holder survive a hit that would
+	// have otherwise made it faint.
+	Endured bool
+	// Immune is true if the ability made the holder immune to a move.
+	Immune bool
+	// PreventedAilment is true if the ability kept the holder from getting an
+	// ailment.
+	PreventedAilment bool
+	// WeatherStarted is the weather the ability started, if any.
+	WeatherStarted Weather
+}
+
+// abilityHooks contains the functions that apply the effects of an ability
+// at specific points in a battle. Any of these hooks may be nil if the ability
+// does nothing at that point.
+type abilityHooks struct {
+	// switchIn is run when the holder enters the battle.
+	switchIn func(b *Battle, holder, opponent *Pokemon, holderBI, opponentBI *PokemonBattleInfo) AbilityReport
+	// damageCalc returns the multiplier the ability applies to the damage of
+	// the given move. It is run for both the user and the target of a move,
+	// and attacking is true if the holder is the one using the move.
+	damageCalc func(holder *Pokemon, holderBI *PokemonBattleInfo, move Move, attacking bool) float64
+	// damageTaken is run right before the holder takes damage from a move
+	// used by the attacker. It returns the damage the holder should actually
+	// take.
+	damageTaken func(holder, attacker *Pokemon, holderBI, attackerBI *PokemonBattleInfo, move Move, damage int) (int, AbilityReport)
+	// status returns false if the ability prevents the holder from getting
+	// the given ailment.
+	status func(holder *Pokemon, holderBI *PokemonBattleInfo, ailment Ailment) bool
+	// speed returns the multiplier the ability applies to the holder's speed.
+	speed func(holder *Pokemon, holderBI *PokemonBattleInfo, weather Weather) float64
+	// endOfTurn is run after both trainers have finished their turn.
+	endOfTurn func(holder *Pokemon, holderBI *PokemonBattleInfo) AbilityReport
+}
+
+// abilities maps the PokeAPI names of all abilities that have an effect in
+// battle to their hooks. Abilities not in this map do nothing.
+var abilities map[string]abilityHooks
+
+// Fill the ability map. This has to be done in init because some hooks look
+// up abilities themselves.
+func init() {
+	abilities = map[string]abilityHooks{
+		"intimidate":   {switchIn: intimidateSwitchIn},
+		"overgrow":     {damageCalc: pinchDamageCalc("grass")},
+		"blaze":        {damageCalc: pinchDamageCalc("fire")},
+		"torrent":      {damageCalc: pinchDamageCalc("water")},
+		"static":       {damageTaken: staticDamageTaken},
+		"levitate":     {damageCalc: levitateDamageCalc},
+		"sturdy":       {damageTaken: sturdyDamageTaken},
+		"swift-swim":   {speed: swiftSwimSpeed},
+		"limber":       {status: limberStatus},
+		"speed-boost":  {endOfTurn: speedBoostEndOfTurn},
+		"drizzle":      {switchIn: weatherSwitchIn(RainWeather)},
+		"drought":      {switchIn: weatherSwitchIn(SunWeather)},
+		"sand-stream":  {switchIn: weatherSwitchIn(SandstormWeather)},
+		"snow-warning": {switchIn: weatherSwitchIn(HailWeather)}}
+}
+
+// hooksOf returns the ability hooks of the given Pokemon.
+func hooksOf(p *Pokemon) abilityHooks {
+	return abilities[p.Ability]
+}
+
+// intimidateSwitchIn lowers the opponent's attack by one stage.
+func intimidateSwitchIn(b *Battle, holder, opponent *Pokemon, holderBI, opponentBI *PokemonBattleInfo) AbilityReport {
+	return AbilityReport{
+		Activated:              true,
+		Ability:                holder.Ability,
+		OpponentAttStageChange: opponentBI.ChangeStatStage(AttackStatType, -1)}
+}
+
+// weatherSwitchIn creates a switch in hook that starts the given weather.
+// This is the effect of Drizzle, Drought, Sand Stream and Snow Warning.
+func weatherSwitchIn(weather Weather) func(*Battle, *Pokemon, *Pokemon, *PokemonBattleInfo, *PokemonBattleInfo) AbilityReport {
+	return func(b *Battle, holder, opponent *Pokemon, holderBI, opponentBI *PokemonBattleInfo) AbilityReport {
+		if !b.StartWeather(weather) {
+			return AbilityReport{}
+		}
+		return AbilityReport{
+			Activated:      true,
+			Ability:        holder.Ability,
+			WeatherStarted: weather}
+	}
+}
+
+// pinchDamageCalc creates a damage calculation hook that powers up moves of
+// the given type by 50% when the holder is at or below a third of its max
+// HP. This is the effect of Overgrow, Blaze and Torrent.
+func pinchDamageCalc(moveType string) func(*Pokemon, *PokemonBattleInfo, Move, bool) float64 {
+	return func(holder *Pokemon, holderBI *PokemonBattleInfo, move Move, attacking bool) float64 {
+		if attacking && move.Type == moveType && holderBI.CurrHP*3 <= CalcIBHP(*holder, *holderBI) {
+			return 1.5
+		}
+		return 1.0
+	}
+}
+
+// levitateDamageCalc makes the holder immune to ground type moves.
+func levitateDamageCalc(holder *Pokemon, holderBI *PokemonBattleInfo, move Move, attacking bool) float64 {
+	if !attacking && move.Type == "ground" {
+		return 0.0
+	}
+	return 1.0
+}
+
+// staticDamageTaken has a 30% chance of paralyzing the attacker if the move
+// makes contact. PokeAPI does not say which moves make contact, so all
+// physical moves are assumed to.
+func staticDamageTaken(holder, attacker *Pokemon, holderBI, attackerBI *PokemonBattleInfo, move Move, damage int) (int, AbilityReport) {
+	if move.DamageClass != PhysicalDamageClass || rand.Intn(100) >= 30 {
+		return damage, AbilityReport{}
+	}
+
+	inflicted, _ := inflictAilment(attacker, attackerBI, ParalysisAilment)
+	return damage, AbilityReport{
+		Activated:         inflicted,
+		Ability:           holder.Ability,
+		OpponentParalyzed: inflicted}
+}
+
+// sturdyDamageTaken keeps the holder from fainting in one hit when it is at
+// full HP.
+func sturdyDamageTaken(holder, attacker *Pokemon, holderBI, attackerBI *PokemonBattleInfo, move Move, damage int) (int, AbilityReport) {
+	if holderBI.CurrHP != CalcIBHP(*holder, *holderBI) || damage < holderBI.CurrHP {
+		return damage, AbilityReport{}
+	}
+
+	return holderBI.CurrHP - 1, AbilityReport{
+		Activated: true,
+		Ability:   holder.Ability,
+		Endured:   true}
+}
+
+// swiftSwimSpeed doubles the holder's speed in the rain.
+func swiftSwimSpeed(holder *Pokemon, holderBI *PokemonBattleInfo, weather Weather) float64 {
+	if weather == RainWeather {
+		return 2.0
+	}
+	return 1.0
+}
+
+// limberStatus keeps the holder from being paralyzed.
+func limberStatus(holder *Pokemon, holderBI *PokemonBattleInfo, ailment Ailment) bool {
+	return ailment != ParalysisAilment
+}
+
+// speedBoostEndOfTurn raises the holder's speed by one stage.
+func speedBoostEndOfTurn(holder *Pokemon, holderBI *PokemonBattleInfo) AbilityReport {
+	change := holderBI.ChangeStatStage(SpeedStatType, 1)
+	return AbilityReport{
+		Activated:              change != 0,
+		Ability:                holder.Ability,
+		HolderSpeedStageChange: change}
+}
+
+// inflictAilment gives the Pokemon the ailment so long as it isn't already
+// suffering from one and its ability doesn't prevent it. It returns true if
+// the ailment was inflicted, along with a report of the Pokemon's ability if
+// it prevented the ailment.
+func inflictAilment(p *Pokemon, pBI *PokemonBattleInfo, ailment Ailment) (bool, AbilityReport) {
+	if pBI.Ailment != NoAilment {
+		return false, AbilityReport{}
+	}
+
+	if hooks := hooksOf(p); hooks.status != nil && !hooks.status(p, pBI, ailment) {
+		return false, AbilityReport{
+			Activated:        true,
+			Ability:          p.Ability,
+			PreventedAilment: true}
+	}
+
+	pBI.Ailment = ailment
+	return true, AbilityReport{}
+}
+
+// abilityDamageModifier returns the multiplier that the abilities of the user
+// and target apply to the damage of the move.
+func abilityDamageModifier(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move Move) float64 {
+	modifier := 1.0
+	if hooks := hooksOf(user); hooks.damageCalc != nil {
+		modifier *= hooks.damageCalc(user, userBI, move, true)
+	}
+	if hooks := hooksOf(target); hooks.damageCalc != nil {
+		modifier *= hooks.damageCalc(target, targetBI, move, false)
+	}
+	return modifier
+}
+
+// RunSwitchInAbility applies the effects of the holder's ability when it
+// enters the battle against the opponent.
+func RunSwitchInAbility(b *Battle, holder, opponent *Pokemon, holderBI, opponentBI *PokemonBattleInfo) AbilityReport {
+	if hooks := hooksOf(holder); hooks.switchIn != nil {
+		return hooks.switchIn(b, holder, opponent, holderBI, opponentBI)
+	}
+	return AbilityReport{}
+}
+
+// RunEndOfTurnAbility applies the effects of the holder's ability at the end
+// of a turn.
+func RunEndOfTurnAbility(holder *Pokemon, holderBI *PokemonBattleInfo) AbilityReport {
+	if hooks := hooksOf(holder); hooks.endOfTurn != nil {
+		return hooks.endOfTurn(holder, holderBI)
+	}
+	return AbilityReport{}
+}
+
+// CalcEffectiveSpeed calculates the speed of the Pokemon used to decide move
+// order, which is the in-battle speed stat with the effects of the Pokemon's
+// ability in the given weather and the conditions on its side of the field
+// applied.
+func CalcEffectiveSpeed(pkmn Pokemon, pkmnBI PokemonBattleInfo, side TrainerBattleInfo, weather Weather) int {
+	speed := float64(CalcIBSpeed(pkmn, pkmnBI))
+	if hooks := hooksOf(&pkmn); hooks.speed != nil {
+		speed *= hooks.speed(&pkmn, &pkmnBI, weather)
+	}
+	speed *= tailwindSpeedModifier(side)
+	return int(speed)
+}
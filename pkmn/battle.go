@@ -11,6 +11,7 @@ const (
 	MoveBattleActionType
 	SwitchBattleActionType
 	CatchBattleActionType
+	ItemBattleActionType
 )
 
 // BattleActionTypePriority returns a number representing the relative priority
@@ -24,8 +25,10 @@ func BattleActionTypePriority(t BattleActionType) int {
 		return 0
 	case SwitchBattleActionType:
 		return 1
-	case CatchBattleActionType:
+	case ItemBattleActionType:
 		return 2
+	case CatchBattleActionType:
+		return 3
 	default:
 		panic(fmt.Sprintf("invalid battle action type %v", t))
 	}
@@ -33,10 +36,12 @@ func BattleActionTypePriority(t BattleActionType) int {
 
 // BattleAction represents a single action made by a trainer. The val parameter
 // is a generic value that means different things depending on the battle
-// action type.
+// action type. The target parameter is the party slot the action is directed
+// at, for actions that need one.
 type BattleAction struct {
-	Type BattleActionType
-	Val  int
+	Type   BattleActionType
+	Val    int
+	Target int
 }
 
 // PokemonBattleInfo contains information on the battling status of a single
@@ -56,6 +61,36 @@ type PokemonBattleInfo struct {
 
 	Ailment  Ailment
 	Confused bool
+
+	// ChoiceLockedMove is the ID of the move the Pokemon has to keep using
+	// because of its Choice Band, or 0 if it isn't locked into a move.
+	ChoiceLockedMove int
+
+	// ChargingMove is the ID of the two turn move the Pokemon is charging up,
+	// or 0 if it isn't charging anything.
+	ChargingMove int
+	// SemiInvulnerable is true while the Pokemon is out of reach charging up a
+	// move like Fly or Dig, so other moves can't hit it.
+	SemiInvulnerable bool
+	// RechargeMove is the ID of the move the Pokemon has to recharge from on
+	// its next turn, or 0 if it doesn't have to recharge.
+	RechargeMove int
+	// RampageMove is the ID of the move the Pokemon is rampaging with, like
+	// Outrage, and RampageTurns is the number of turns it has left to use it.
+	RampageMove  int
+	RampageTurns int
+	// ProtectCount is the number of times in a row the Pokemon has
+	// successfully protected itself. Each one makes the next less likely to
+	// work.
+	ProtectCount int
+	// Protected is true if the Pokemon is protected from moves for the rest
+	// of the turn.
+	Protected bool
+
+	// Opponents are the UUIDs of the opposing Pokemon that have been in
+	// battle at the same time as this one. They share the experience this
+	// Pokemon gives when it faints.
+	Opponents []string
 }
 
 // TrainerBattleInfo contains information on the battling status of a single
@@ -66,6 +101,20 @@ type TrainerBattleInfo struct {
 	FinishedTurn     bool
 	NextBattleAction BattleAction
 	CurrPkmnSlot     int
+	// MustReplace is true if the trainer's active Pokemon has fainted and
+	// they have to send out a replacement before doing anything else.
+	MustReplace bool
+
+	// Entry hazards laid on this trainer's side of the field. Spikes and
+	// Toxic Spikes can be laid in multiple layers.
+	StealthRock bool
+	Spikes      int
+	ToxicSpikes int
+	// The number of turns left in the conditions protecting this trainer's
+	// side of the field, or 0 if they aren't in effect.
+	ReflectTurns     int
+	LightScreenTurns int
+	TailwindTurns    int
 }
 
 // BattleMode represents what point the battle is in.
@@ -83,5 +132,35 @@ type Battle struct {
 	P1 string
 	P2 string
 
-	Mode BattleMode
+	Mode    BattleMode
+	Weather Weather
+	// WeatherTurns is the number of turns left before the weather clears.
+	WeatherTurns int
+	// Turn is the number of turns that have been completed in the battle.
+	Turn int
+	// GymBattle is true if the battle is against a gym leader, in which case
+	// GymRegion is the region whose badge is on the line.
+	GymBattle bool
+	GymRegion Region
+}
+
+// Face records that the two Pokemon have been in battle against each other.
+func Face(p1, p2 *Pokemon, p1BI, p2BI *PokemonBattleInfo) {
+	if !p1BI.FoughtBy(p2.UUID) {
+		p1BI.Opponents = append(p1BI.Opponents, p2.UUID)
+	}
+	if !p2BI.FoughtBy(p1.UUID) {
+		p2BI.Opponents = append(p2BI.Opponents, p1.UUID)
+	}
+}
+
+// FoughtBy returns true if the Pokemon with the given UUID has been in battle
+// against this Pokemon.
+func (pkmnBI *PokemonBattleInfo) FoughtBy(pkmnUUID string) bool {
+	for _, uuid := range pkmnBI.Opponents {
+		if uuid == pkmnUUID {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,20 @@
+package pkmn
+
+const (
+	// BoxCount is the number of boxes every trainer has in their PC.
+	BoxCount = 8
+	// BoxSize is the number of Pokemon a single PC box can hold.
+	BoxSize = 30
+)
+
+// FirstBoxWithSpace returns the index of the first box that has room for
+// another Pokemon, given the number of Pokemon in each box. False is returned
+// if every box is full.
+func FirstBoxWithSpace(boxSizes []int) (int, bool) {
+	for box, size := range boxSizes {
+		if size < BoxSize {
+			return box, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+package pkmn
+
+import (
+	"math"
+	"math/rand"
+)
+
+// CatchConditions describes the circumstances a ball is thrown in. Some balls
+// work better in certain circumstances.
+type CatchConditions struct {
+	// Turn is the number of turns that have been completed in the battle, so
+	// it is 0 on the first turn.
+	Turn int
+	// Night is true if it's currently night time.
+	Night bool
+	// SpeciesCaught is the number of different species the trainer has
+	// caught, which decides how likely a critical capture is.
+	SpeciesCaught int
+}
+
+// CatchReport describes what happened when a ball was thrown at a Pokemon.
+type CatchReport struct {
+	Caught bool
+	// Shakes is the number of times the ball shook before the Pokemon was
+	// caught or broke out, from 0 to 3.
+	Shakes int
+	// Critical is true if the throw was a critical capture, which only
+	// needs the ball to shake once to catch the Pokemon.
+	Critical bool
+}
+
+// BallBonus returns the multiplier that the ball applies to the catch rate of
+// the target Pokemon in the given conditions.
+func BallBonus(ball Item, target Pokemon, cond CatchConditions) float64 {
+	switch ball {
+	case GreatBallItem:
+		return 1.5
+	case UltraBallItem:
+		return 2.0
+	case NetBallItem:
+		// Net Balls work well on water and bug type Pokemon
+		if target.Type1 == "water" || target.Type2 == "water" ||
+			target.Type1 == "bug" || target.Type2 == "bug" {
+			return 3.5
+		}
+		return 1.0
+	case QuickBallItem:
+		// Quick Balls work well at the very start of a battle
+		if cond.Turn == 0 {
+			return 5.0
+		}
+		return 1.0
+	case DuskBallItem:
+		// Dusk Balls work well at night
+		if cond.Night {
+			return 3.5
+		}
+		return 1.0
+	default:
+		return 1.0
+	}
+}
+
+// ailmentCatchBonus returns the multiplier that the given ailment applies to
+// the catch rate.
+func ailmentCatchBonus(ailment Ailment) float64 {
+	switch ailment {
+	case SleepAilment, FreezeAilment:
+		return 2.5
+	case ParalysisAilment, PoisonAilment, BurnAilment:
+		return 1.5
+	default:
+		return 1.0
+	}
+}
+
+// criticalCaptureMultiplier returns the multiplier applied to the chance of a
+// critical capture based on how many species the trainer has caught.
+func criticalCaptureMultiplier(speciesCaught int) float64 {
+	switch {
+	case speciesCaught > 600:
+		return 2.5
+	case speciesCaught > 450:
+		return 2.0
+	case speciesCaught > 300:
+		return 1.5
+	case speciesCaught > 150:
+		return 1.0
+	case speciesCaught > 30:
+		return 0.5
+	default:
+		return 0.0
+	}
+}
+
+// CatchRate returns the modified catch rate of the Pokemon when the given
+// ball is thrown at it in the given conditions. A catch rate of 255 or more
+// means that the Pokemon will certainly be caught.
+func CatchRate(p Pokemon, pBI PokemonBattleInfo, ball Item, cond CatchConditions) float64 {
+	maxHP := float64(CalcIBHP(p, pBI))
+	currHP := float64(pBI.CurrHP)
+
+	return (((3.0*maxHP - 2.0*currHP) * float64(p.CatchRate) * BallBonus(ball, p, cond)) / (3.0 * maxHP)) *
+		ailmentCatchBonus(pBI.Ailment)
+}
+
+// ThrowBall throws the ball at the Pokemon and reports whether or not it was
+// caught. Like in the main series games, the ball has to pass three shake
+// checks for the Pokemon to be caught, or just one for a critical capture.
+func ThrowBall(p Pokemon, pBI PokemonBattleInfo, ball Item, cond CatchConditions) CatchReport {
+	var cr CatchReport
+
+	rate := CatchRate(p, pBI, ball, cond)
+	if rate >= 255.0 {
+		// The Pokemon is guaranteed to be caught
+		cr.Caught = true
+		cr.Shakes = 3
+		return cr
+	}
+
+	// Check for a critical capture
+	critChance := math.Min(255.0, rate) * criticalCaptureMultiplier(cond.SpeciesCaught) / 6.0
+	cr.Critical = float64(rand.Intn(256)) < critChance
+
+	// Find the chance that the ball passes a single shake check, out of
+	// 65536
+	shakeChance := 65536.0 / math.Pow(255.0/rate, 3.0/16.0)
+
+	checks := 3
+	if cr.Critical {
+		checks = 1
+	}
+	for i := 0; i < checks; i++ {
+		if float64(rand.Intn(65536)) >= shakeChance {
+			// The Pokemon broke out
+			return cr
+		}
+		cr.Shakes++
+	}
+
+	cr.Caught = true
+	return cr
+}
@@ -0,0 +1,58 @@
+package pkmn
+
+import "time"
+
+// Clock tells the current time. Anything that depends on the time of day
+// takes a Clock so that a fake one can be used in its place.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that tells the actual current time.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (c SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// TimeOfDay is a part of the day that some events depend on.
+type TimeOfDay int
+
+const (
+	// AnyTimeOfDay is used for things that may happen at any time.
+	AnyTimeOfDay TimeOfDay = iota
+	DayTimeOfDay
+	NightTimeOfDay
+)
+
+var timeOfDayNames = map[TimeOfDay]string{
+	AnyTimeOfDay:   "any",
+	DayTimeOfDay:   "day",
+	NightTimeOfDay: "night"}
+
+// Name returns the lowercase name of the time of day.
+func (tod TimeOfDay) Name() string {
+	return timeOfDayNames[tod]
+}
+
+// NameToTimeOfDay returns the time of day with the given lowercase name, or
+// false if no such time of day exists.
+func NameToTimeOfDay(name string) (TimeOfDay, bool) {
+	for tod, todName := range timeOfDayNames {
+		if todName == name {
+			return tod, true
+		}
+	}
+	return AnyTimeOfDay, false
+}
+
+// TimeOfDayAt returns the part of the day the given time is in. Night lasts
+// from 8 PM to 6 AM.
+func TimeOfDayAt(t time.Time) TimeOfDay {
+	hour := t.Hour()
+	if hour >= 20 || hour < 6 {
+		return NightTimeOfDay
+	}
+	return DayTimeOfDay
+}
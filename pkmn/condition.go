@@ -0,0 +1,135 @@
+package pkmn
+
+import "time"
+
+const (
+	// HealCooldown is how long a trainer has to wait between visits to the
+	// Pokemon Center.
+	HealCooldown = 30 * time.Minute
+	// StruggleMoveID is the PokeAPI ID of Struggle, the move a Pokemon uses
+	// when none of its moves have any PP left.
+	StruggleMoveID = 165
+)
+
+// CurrHP returns how much HP the Pokemon has left outside of battle.
+func (p *Pokemon) CurrHP() int {
+	hp := CalcOOBHP(p.HP, *p) - p.Damage
+	if hp < 0 {
+		return 0
+	}
+	return hp
+}
+
+// Fainted returns true if the Pokemon has no HP left and can't battle until
+// it's healed.
+func (p *Pokemon) Fainted() bool {
+	return p.CurrHP() <= 0
+}
+
+// NewBattleInfo returns battle info for the Pokemon at the start of a battle.
+// The Pokemon starts the battle with whatever HP and status it had left over
+// from the last one.
+func (p *Pokemon) NewBattleInfo() PokemonBattleInfo {
+	return PokemonBattleInfo{
+		PkmnUUID: p.UUID,
+		CurrHP:   p.CurrHP(),
+		Ailment:  p.Ailment}
+}
+
+// KeepCondition copies the Pokemon's HP and status out of its battle info so
+// that they carry over to the next battle.
+func (p *Pokemon) KeepCondition(pBI PokemonBattleInfo) {
+	p.Damage = CalcOOBHP(p.HP, *p) - pBI.CurrHP
+	if p.Damage < 0 {
+		p.Damage = 0
+	}
+	p.Ailment = pBI.Ailment
+}
+
+// moveSlotOf returns the move slot the move with the given ID is in, starting
+// at 1, or 0 if the Pokemon doesn't know the move.
+func (p *Pokemon) moveSlotOf(moveID int) int {
+	switch {
+	case moveID == 0:
+		return 0
+	case p.Move1 == moveID:
+		return 1
+	case p.Move2 == moveID:
+		return 2
+	case p.Move3 == moveID:
+		return 3
+	case p.Move4 == moveID:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// ppUsed returns how many times the move in the given move slot, starting at
+// 1, has been used since the Pokemon was last healed.
+func (p *Pokemon) ppUsed(moveSlot int) int {
+	if moveSlot < 1 || moveSlot > len(p.PPUsed) {
+		return 0
+	}
+	return p.PPUsed[moveSlot-1]
+}
+
+// restorePP restores all the PP of the move in the given move slot, starting
+// at 1.
+func (p *Pokemon) restorePP(moveSlot int) {
+	if moveSlot >= 1 && moveSlot <= len(p.PPUsed) {
+		p.PPUsed[moveSlot-1] = 0
+	}
+}
+
+// PPLeft returns how many more times the Pokemon can use the given move before
+// it needs to be healed.
+func (p *Pokemon) PPLeft(move Move) int {
+	left := move.PP - p.ppUsed(p.moveSlotOf(move.ID))
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// HasPP returns true if the Pokemon can still use the given move. Moves
+// without any PP information can always be used.
+func (p *Pokemon) HasPP(move Move) bool {
+	return move.PP == 0 || p.PPLeft(move) > 0
+}
+
+// UsePP uses up one PP of the move with the given ID. Nothing happens if the
+// Pokemon doesn't know the move, like when it's struggling.
+func (p *Pokemon) UsePP(moveID int) {
+	moveSlot := p.moveSlotOf(moveID)
+	if moveSlot == 0 {
+		return
+	}
+	for len(p.PPUsed) < moveSlot {
+		p.PPUsed = append(p.PPUsed, 0)
+	}
+	p.PPUsed[moveSlot-1]++
+}
+
+// Heal restores all of the Pokemon's HP and PP and cures its status
+// condition.
+func (p *Pokemon) Heal() {
+	p.Damage = 0
+	p.Ailment = NoAilment
+	p.PPUsed = nil
+}
+
+// CanHeal returns true if enough time has passed since the trainer last had
+// their party healed for them to do so again.
+func (t *Trainer) CanHeal(clock Clock) bool {
+	return t.LastHeal.IsZero() || clock.Now().Sub(t.LastHeal) >= HealCooldown
+}
+
+// NextHeal returns how long the trainer has to wait before they can have their
+// party healed again.
+func (t *Trainer) NextHeal(clock Clock) time.Duration {
+	if t.CanHeal(clock) {
+		return 0
+	}
+	return HealCooldown - clock.Now().Sub(t.LastHeal)
+}
@@ -0,0 +1,150 @@
+package pkmn
+
+// EvolutionTrigger is the kind of event that makes a Pokemon evolve.
+type EvolutionTrigger int
+
+const (
+	_ EvolutionTrigger = iota
+	// LevelUpEvolutionTrigger evolutions happen when the Pokemon levels up.
+	LevelUpEvolutionTrigger
+	// UseItemEvolutionTrigger evolutions happen when the trainer uses an
+	// item, like an evolution stone, on the Pokemon.
+	UseItemEvolutionTrigger
+	// TradeEvolutionTrigger evolutions happen when the Pokemon is traded to
+	// another trainer.
+	TradeEvolutionTrigger
+)
+
+// Evolution describes a species a Pokemon can evolve into and what it takes
+// to get there. Conditions that are left at their zero value don't apply.
+type Evolution struct {
+	// SpeciesID is the PokeAPI ID of the species the Pokemon evolves into.
+	SpeciesID int
+	Trigger   EvolutionTrigger
+	// MinLevel is the level the Pokemon has to be at.
+	MinLevel int
+	// Item is the item that has to be used on the Pokemon for a use item
+	// evolution.
+	Item Item
+	// HeldItem is the item the Pokemon has to be holding. The item is used up
+	// by the evolution.
+	HeldItem Item
+	// MinFriendship is the friendship the Pokemon needs to have.
+	MinFriendship int
+	// TimeOfDay is the part of the day the evolution has to happen in.
+	TimeOfDay TimeOfDay
+}
+
+// conditionsMet returns true if the Pokemon meets all the conditions of the
+// evolution other than its trigger.
+func (evo Evolution) conditionsMet(p Pokemon, clock Clock) bool {
+	if p.Level < evo.MinLevel {
+		return false
+	}
+	if evo.HeldItem != NoItem && p.HeldItem != evo.HeldItem {
+		return false
+	}
+	if p.Friendship < evo.MinFriendship {
+		return false
+	}
+	if evo.TimeOfDay != AnyTimeOfDay && TimeOfDayAt(clock.Now()) != evo.TimeOfDay {
+		return false
+	}
+	return true
+}
+
+// LevelEvolution returns the evolution out of the given ones that the Pokemon
+// is ready for now that it has leveled up, or false if there is none.
+// Evolutions the trainer already cancelled at the Pokemon's current level are
+// not offered again until it levels up.
+func LevelEvolution(p Pokemon, evolutions []Evolution, clock Clock) (Evolution, bool) {
+	if p.CancelledEvolutionLevel == p.Level {
+		return Evolution{}, false
+	}
+
+	for _, evo := range evolutions {
+		if evo.Trigger == LevelUpEvolutionTrigger && evo.conditionsMet(p, clock) {
+			return evo, true
+		}
+	}
+	return Evolution{}, false
+}
+
+// ItemEvolution returns the evolution out of the given ones that using the
+// item on the Pokemon would cause, or false if the item would have no effect.
+func ItemEvolution(p Pokemon, evolutions []Evolution, item Item, clock Clock) (Evolution, bool) {
+	for _, evo := range evolutions {
+		if evo.Trigger == UseItemEvolutionTrigger && evo.Item == item && evo.conditionsMet(p, clock) {
+			return evo, true
+		}
+	}
+	return Evolution{}, false
+}
+
+// TradeEvolution returns the evolution out of the given ones that being
+// traded would cause, or false if the Pokemon doesn't evolve when traded.
+func TradeEvolution(p Pokemon, evolutions []Evolution, clock Clock) (Evolution, bool) {
+	for _, evo := range evolutions {
+		if evo.Trigger == TradeEvolutionTrigger && evo.conditionsMet(p, clock) {
+			return evo, true
+		}
+	}
+	return Evolution{}, false
+}
+
+// Evolve turns the Pokemon into the evolved form. The Pokemon takes on the
+// species, form, types, base stats and sprites of the evolved form, but keeps
+// its IVs, EVs, moves, level, experience, shininess and everything else that
+// is particular to it. Its new ability is the one in the same slot out of the
+// given regular abilities of the evolved form, keyed by slot.
+func (p *Pokemon) Evolve(evolved Pokemon, abilities map[int]string) {
+	p.ID = evolved.ID
+	p.Name = evolved.Name
+	p.Form = evolved.Form
+	p.SpriteURL = evolved.SpriteURL
+	p.ShinySpriteURL = evolved.ShinySpriteURL
+	p.Height = evolved.Height
+	p.Weight = evolved.Weight
+	p.Type1 = evolved.Type1
+	p.Type2 = evolved.Type2
+	p.CatchRate = evolved.CatchRate
+	p.BaseExperience = evolved.BaseExperience
+
+	// Only the base stats and effort value yields belong to the species
+	stats := []struct{ stat, evolvedStat *Stat }{
+		{&p.HP, &evolved.HP},
+		{&p.Attack, &evolved.Attack},
+		{&p.Defense, &evolved.Defense},
+		{&p.SpAttack, &evolved.SpAttack},
+		{&p.SpDefense, &evolved.SpDefense},
+		{&p.Speed, &evolved.Speed}}
+	for _, s := range stats {
+		s.stat.Base = s.evolvedStat.Base
+		s.stat.EVYield = s.evolvedStat.EVYield
+	}
+
+	// Pokemon that don't know their ability slot take on the one the evolved
+	// form was given
+	if p.AbilitySlot == 0 {
+		p.AbilitySlot = evolved.AbilitySlot
+	}
+	if ability, ok := abilities[p.AbilitySlot]; ok {
+		p.Ability = ability
+	} else if ability, ok := abilities[1]; ok {
+		// The evolved form has fewer abilities, but the Pokemon keeps its
+		// slot in case it evolves again
+		p.Ability = ability
+	} else {
+		p.Ability = evolved.Ability
+	}
+
+	p.PendingEvolution = 0
+	p.CancelledEvolutionLevel = 0
+}
+
+// CancelEvolution stops the Pokemon's pending evolution. It won't try to
+// evolve again until it levels up.
+func (p *Pokemon) CancelEvolution() {
+	p.PendingEvolution = 0
+	p.CancelledEvolutionLevel = p.Level
+}
@@ -0,0 +1,128 @@
+package pkmn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvolveAbility(t *testing.T) {
+	// Two abilities, as the evolved form of a Pokemon would have
+	twoAbilities := map[int]string{1: "overgrow", 2: "chlorophyll"}
+	oneAbility := map[int]string{1: "levitate"}
+
+	tests := []struct {
+		name      string
+		slot      int
+		abilities map[int]string
+		// rolledSlot is the slot the evolved form was given when it was made
+		rolledSlot  int
+		wantAbility string
+		wantSlot    int
+	}{
+		{"first slot", 1, twoAbilities, 2, "overgrow", 1},
+		{"second slot", 2, twoAbilities, 1, "chlorophyll", 2},
+		{"second slot with one ability", 2, oneAbility, 1, "levitate", 2},
+		{"unknown slot", 0, twoAbilities, 2, "chlorophyll", 2},
+	}
+
+	for _, test := range tests {
+		p := Pokemon{ID: 1, Ability: "old", AbilitySlot: test.slot}
+		evolved := Pokemon{ID: 2, Ability: test.abilities[test.rolledSlot], AbilitySlot: test.rolledSlot}
+		p.Evolve(evolved, test.abilities)
+		if p.Ability != test.wantAbility || p.AbilitySlot != test.wantSlot {
+			t.Errorf("%v: got ability %v in slot %v, want %v in slot %v",
+				test.name, p.Ability, p.AbilitySlot, test.wantAbility, test.wantSlot)
+		}
+	}
+}
+
+// clockAt returns a Clock that is stuck at the given hour and minute.
+func clockAt(hour, minute int) Clock {
+	return fixedClock(time.Date(2016, time.May, 1, hour, minute, 0, 0, time.UTC))
+}
+
+func TestTimeOfDayAt(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		want         TimeOfDay
+	}{
+		{0, 0, NightTimeOfDay},
+		{5, 59, NightTimeOfDay},
+		{6, 0, DayTimeOfDay},
+		{12, 0, DayTimeOfDay},
+		{19, 59, DayTimeOfDay},
+		{20, 0, NightTimeOfDay},
+		{23, 59, NightTimeOfDay},
+	}
+
+	for _, test := range tests {
+		if got := TimeOfDayAt(clockAt(test.hour, test.minute).Now()); got != test.want {
+			t.Errorf("TimeOfDayAt(%02d:%02d) = %v, want %v", test.hour, test.minute, got.Name(), test.want.Name())
+		}
+	}
+}
+
+func TestLevelEvolutionTimeOfDay(t *testing.T) {
+	// Like Eevee, which evolves into Espeon by day and Umbreon by night once
+	// it's friendly enough
+	evolutions := []Evolution{
+		{SpeciesID: 196, Trigger: LevelUpEvolutionTrigger, MinFriendship: 220, TimeOfDay: DayTimeOfDay},
+		{SpeciesID: 197, Trigger: LevelUpEvolutionTrigger, MinFriendship: 220, TimeOfDay: NightTimeOfDay},
+	}
+
+	tests := []struct {
+		name         string
+		hour, minute int
+		friendship   int
+		cancelled    bool
+		want         int
+	}{
+		{"morning", 6, 0, 220, false, 196},
+		{"evening", 19, 59, 255, false, 196},
+		{"night", 20, 0, 220, false, 197},
+		{"early morning", 5, 59, 220, false, 197},
+		{"unfriendly", 12, 0, 219, false, 0},
+		{"cancelled", 22, 0, 220, true, 0},
+	}
+
+	for _, test := range tests {
+		p := Pokemon{ID: 133, Level: 20, Friendship: test.friendship}
+		if test.cancelled {
+			p.CancelEvolution()
+		}
+		evo, ok := LevelEvolution(p, evolutions, clockAt(test.hour, test.minute))
+		if ok != (test.want != 0) || evo.SpeciesID != test.want {
+			t.Errorf("%v: got evolution into %v (%v), want %v", test.name, evo.SpeciesID, ok, test.want)
+		}
+	}
+}
+
+func TestItemEvolutionTimeOfDay(t *testing.T) {
+	// An item evolution that only works at night, and another one that works
+	// at any time
+	evolutions := []Evolution{
+		{SpeciesID: 2, Trigger: UseItemEvolutionTrigger, Item: DuskStoneItem, TimeOfDay: NightTimeOfDay},
+		{SpeciesID: 3, Trigger: UseItemEvolutionTrigger, Item: MoonStoneItem},
+	}
+
+	tests := []struct {
+		name         string
+		item         Item
+		hour, minute int
+		want         int
+	}{
+		{"night item at night", DuskStoneItem, 21, 0, 2},
+		{"night item by day", DuskStoneItem, 13, 0, 0},
+		{"any time item at night", MoonStoneItem, 21, 0, 3},
+		{"any time item by day", MoonStoneItem, 13, 0, 3},
+		{"unrelated item", FireStoneItem, 21, 0, 0},
+	}
+
+	for _, test := range tests {
+		p := Pokemon{ID: 1, Level: 30}
+		evo, ok := ItemEvolution(p, evolutions, test.item, clockAt(test.hour, test.minute))
+		if ok != (test.want != 0) || evo.SpeciesID != test.want {
+			t.Errorf("%v: got evolution into %v (%v), want %v", test.name, evo.SpeciesID, ok, test.want)
+		}
+	}
+}
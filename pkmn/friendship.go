@@ -0,0 +1,49 @@
+package pkmn
+
+import "time"
+
+const (
+	// MaxFriendship is the highest friendship a Pokemon can have.
+	MaxFriendship = 255
+	// WalkFriendship is how much friendship each Pokemon in the party gains
+	// when the trainer takes them for a walk.
+	WalkFriendship = 3
+	// WalkCooldown is how long a trainer has to wait between walks.
+	WalkCooldown = time.Hour
+)
+
+// RaiseFriendship increases the Pokemon's friendship by the given amount, up
+// to MaxFriendship.
+func (p *Pokemon) RaiseFriendship(amount int) {
+	p.Friendship += amount
+	if p.Friendship > MaxFriendship {
+		p.Friendship = MaxFriendship
+	}
+}
+
+// LevelUpFriendship returns how much friendship the Pokemon gains when it
+// levels up. Pokemon that are already friendly gain less.
+func (p *Pokemon) LevelUpFriendship() int {
+	switch {
+	case p.Friendship < 100:
+		return 5
+	case p.Friendship < 200:
+		return 4
+	default:
+		return 3
+	}
+}
+
+// CanWalk returns true if enough time has passed since the trainer's last
+// walk for them to go on another one.
+func (t *Trainer) CanWalk(clock Clock) bool {
+	return t.LastWalk.IsZero() || clock.Now().Sub(t.LastWalk) >= WalkCooldown
+}
+
+// NextWalk returns how long the trainer has to wait before their next walk.
+func (t *Trainer) NextWalk(clock Clock) time.Duration {
+	if t.CanWalk(clock) {
+		return 0
+	}
+	return WalkCooldown - clock.Now().Sub(t.LastWalk)
+}
@@ -0,0 +1,243 @@
+package pkmn
+
+// GymLeaderPokemon is a member of a gym leader's team. Gym leader Pokemon
+// always have the same level and moves, as opposed to wild Pokemon.
+type GymLeaderPokemon struct {
+	// ID is the PokeAPI ID of the Pokemon.
+	ID    int
+	Level int
+	// MoveIDs are the PokeAPI IDs of the Pokemon's moves, at most four.
+	MoveIDs []int
+}
+
+// GymLeader describes a gym leader that trainers may challenge for a badge.
+type GymLeader struct {
+	Name string
+	// Badge is the name of the badge the gym leader gives out when beaten.
+	Badge string
+	// Specialty is the type the gym leader's team revolves around.
+	Specialty string
+	Team      []GymLeaderPokemon
+	// Strategy is how the gym leader picks actions in battle. Later gym
+	// leaders think further ahead.
+	Strategy BattleStrategy
+	// Items are the items the gym leader brings to the battle.
+	Items []BagEntry
+}
+
+// gymLeaders contains the gym leaders of each region in the order trainers
+// have to beat them.
+var gymLeaders = map[Region][]GymLeader{
+	KantoRegion: {
+		{Name: "Brock", Badge: "Boulder Badge", Specialty: "rock", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 74, Level: 12, MoveIDs: []int{33, 106, 88}},
+			{ID: 95, Level: 14, MoveIDs: []int{33, 106, 88, 20}}}},
+		{Name: "Misty", Badge: "Cascade Badge", Specialty: "water", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 120, Level: 18, MoveIDs: []int{33, 55, 106}},
+			{ID: 121, Level: 21, MoveIDs: []int{33, 61, 129, 106}}}},
+		{Name: "Lt. Surge", Badge: "Thunder Badge", Specialty: "electric", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 100, Level: 21, MoveIDs: []int{33, 103, 49, 84}},
+			{ID: 25, Level: 18, MoveIDs: []int{84, 98, 86}},
+			{ID: 26, Level: 24, MoveIDs: []int{85, 98, 86, 21}}}},
+		{Name: "Erika", Badge: "Rainbow Badge", Specialty: "grass", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 71, Level: 29, MoveIDs: []int{75, 22, 77, 51}},
+			{ID: 114, Level: 24, MoveIDs: []int{72, 22, 20}},
+			{ID: 45, Level: 29, MoveIDs: []int{80, 72, 79, 51}}}},
+		{Name: "Koga", Badge: "Soul Badge", Specialty: "poison", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 109, Level: 37, MoveIDs: []int{33, 123, 124, 120}},
+			{ID: 89, Level: 39, MoveIDs: []int{124, 106, 139, 107}},
+			{ID: 110, Level: 43, MoveIDs: []int{123, 124, 108, 120}}}},
+		{Name: "Sabrina", Badge: "Marsh Badge", Specialty: "psychic", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 64, Level: 38, MoveIDs: []int{93, 60, 105}},
+			{ID: 122, Level: 37, MoveIDs: []int{93, 113, 115, 60}},
+			{ID: 49, Level: 38, MoveIDs: []int{60, 48, 93, 77}},
+			{ID: 65, Level: 43, MoveIDs: []int{94, 60, 105, 115}}}},
+		{Name: "Blaine", Badge: "Volcano Badge", Specialty: "fire", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 58, Level: 42, MoveIDs: []int{52, 44, 36}},
+			{ID: 77, Level: 40, MoveIDs: []int{23, 52, 36}},
+			{ID: 78, Level: 42, MoveIDs: []int{53, 23, 36}},
+			{ID: 59, Level: 47, MoveIDs: []int{126, 53, 44, 36}}}},
+		{Name: "Giovanni", Badge: "Earth Badge", Specialty: "ground", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 111, Level: 45, MoveIDs: []int{30, 23, 89}},
+			{ID: 51, Level: 42, MoveIDs: []int{91, 163, 28}},
+			{ID: 31, Level: 44, MoveIDs: []int{34, 89, 40}},
+			{ID: 34, Level: 45, MoveIDs: []int{30, 89, 40}},
+			{ID: 112, Level: 50, MoveIDs: []int{89, 23, 30, 157}}}}},
+	JohtoRegion: {
+		{Name: "Falkner", Badge: "Zephyr Badge", Specialty: "flying", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 16, Level: 7, MoveIDs: []int{33, 28}},
+			{ID: 17, Level: 9, MoveIDs: []int{33, 16, 28}}}},
+		{Name: "Bugsy", Badge: "Hive Badge", Specialty: "bug", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 11, Level: 14, MoveIDs: []int{33, 106}},
+			{ID: 14, Level: 14, MoveIDs: []int{40, 106}},
+			{ID: 123, Level: 16, MoveIDs: []int{98, 43, 210}}}},
+		{Name: "Whitney", Badge: "Plain Badge", Specialty: "normal", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 35, Level: 18, MoveIDs: []int{3, 227, 118}},
+			{ID: 241, Level: 20, MoveIDs: []int{205, 23, 208}}}},
+		{Name: "Morty", Badge: "Fog Badge", Specialty: "ghost", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 92, Level: 21, MoveIDs: []int{122, 180, 212}},
+			{ID: 93, Level: 23, MoveIDs: []int{95, 122, 109}},
+			{ID: 94, Level: 25, MoveIDs: []int{95, 247, 138, 212}}}},
+		{Name: "Chuck", Badge: "Storm Badge", Specialty: "fighting", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 57, Level: 27, MoveIDs: []int{43, 2, 154}},
+			{ID: 62, Level: 30, MoveIDs: []int{95, 170, 57, 223}}}},
+		{Name: "Jasmine", Badge: "Mineral Badge", Specialty: "steel", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 81, Level: 30, MoveIDs: []int{85, 48, 49, 86}},
+			{ID: 81, Level: 30, MoveIDs: []int{85, 48, 49, 86}},
+			{ID: 208, Level: 35, MoveIDs: []int{103, 88, 231, 201}}}},
+		{Name: "Pryce", Badge: "Glacier Badge", Specialty: "ice", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 86, Level: 27, MoveIDs: []int{29, 196, 62}},
+			{ID: 87, Level: 29, MoveIDs: []int{29, 196, 62}},
+			{ID: 221, Level: 31, MoveIDs: []int{196, 31, 54, 59}}}},
+		{Name: "Clair", Badge: "Rising Badge", Specialty: "dragon", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 148, Level: 37, MoveIDs: []int{86, 57, 21, 225}},
+			{ID: 148, Level: 37, MoveIDs: []int{86, 57, 21, 225}},
+			{ID: 148, Level: 37, MoveIDs: []int{86, 57, 21, 225}},
+			{ID: 230, Level: 40, MoveIDs: []int{108, 57, 63, 225}}}}},
+	HoennRegion: {
+		{Name: "Roxanne", Badge: "Stone Badge", Specialty: "rock", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 74, Level: 12, MoveIDs: []int{33, 111, 88}},
+			{ID: 299, Level: 15, MoveIDs: []int{33, 106, 317, 335}}}},
+		{Name: "Brawly", Badge: "Knuckle Badge", Specialty: "fighting", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 66, Level: 16, MoveIDs: []int{2, 67, 69}},
+			{ID: 307, Level: 16, MoveIDs: []int{93, 96, 67}},
+			{ID: 296, Level: 19, MoveIDs: []int{292, 233, 28}}}},
+		{Name: "Wattson", Badge: "Dynamo Badge", Specialty: "electric", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 81, Level: 22, MoveIDs: []int{351, 48, 86, 49}},
+			{ID: 100, Level: 20, MoveIDs: []int{205, 209, 120, 351}},
+			{ID: 82, Level: 24, MoveIDs: []int{351, 48, 86, 49}}}},
+		{Name: "Flannery", Badge: "Heat Badge", Specialty: "fire", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 218, Level: 24, MoveIDs: []int{315, 123, 113}},
+			{ID: 322, Level: 24, MoveIDs: []int{315, 36, 222}},
+			{ID: 324, Level: 29, MoveIDs: []int{315, 34, 175}}}},
+		{Name: "Norman", Badge: "Balance Badge", Specialty: "normal", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 327, Level: 27, MoveIDs: []int{298, 263, 185}},
+			{ID: 288, Level: 27, MoveIDs: []int{163, 263, 227, 185}},
+			{ID: 289, Level: 31, MoveIDs: []int{263, 281, 227, 303}}}},
+		{Name: "Winona", Badge: "Feather Badge", Specialty: "flying", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 277, Level: 31, MoveIDs: []int{332, 98, 104}},
+			{ID: 279, Level: 30, MoveIDs: []int{55, 48, 182, 332}},
+			{ID: 227, Level: 32, MoveIDs: []int{28, 31, 332, 211}},
+			{ID: 334, Level: 33, MoveIDs: []int{89, 225, 349, 332}}}},
+		{Name: "Tate and Liza", Badge: "Mind Badge", Specialty: "psychic", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 337, Level: 42, MoveIDs: []int{347, 113, 94, 95}},
+			{ID: 338, Level: 42, MoveIDs: []int{241, 76, 94, 53}}}},
+		{Name: "Wallace", Badge: "Rain Badge", Specialty: "water", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 370, Level: 40, MoveIDs: []int{352, 213, 186, 175}},
+			{ID: 340, Level: 42, MoveIDs: []int{240, 352, 133, 89}},
+			{ID: 350, Level: 43, MoveIDs: []int{352, 105, 58, 240}}}}},
+	SinnohRegion: {
+		{Name: "Roark", Badge: "Coal Badge", Specialty: "rock", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 74, Level: 12, MoveIDs: []int{446, 88}},
+			{ID: 95, Level: 12, MoveIDs: []int{446, 88, 103}},
+			{ID: 408, Level: 14, MoveIDs: []int{29, 228, 43}}}},
+		{Name: "Gardenia", Badge: "Forest Badge", Specialty: "grass", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 420, Level: 19, MoveIDs: []int{73, 33, 345, 74}},
+			{ID: 387, Level: 19, MoveIDs: []int{75, 44}},
+			{ID: 407, Level: 22, MoveIDs: []int{78, 345, 447, 73}}}},
+		{Name: "Maylene", Badge: "Cobble Badge", Specialty: "fighting", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 307, Level: 28, MoveIDs: []int{409, 93, 249}},
+			{ID: 67, Level: 29, MoveIDs: []int{2, 70, 249}},
+			{ID: 448, Level: 32, MoveIDs: []int{409, 395, 232, 198}}}},
+		{Name: "Crasher Wake", Badge: "Fen Badge", Specialty: "water", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 130, Level: 27, MoveIDs: []int{44, 362, 239}},
+			{ID: 195, Level: 27, MoveIDs: []int{352, 341, 317}},
+			{ID: 419, Level: 30, MoveIDs: []int{453, 242, 362, 129}}}},
+		{Name: "Fantina", Badge: "Relic Badge", Specialty: "ghost", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 355, Level: 32, MoveIDs: []int{261, 248, 425, 228}},
+			{ID: 93, Level: 34, MoveIDs: []int{421, 389, 109}},
+			{ID: 429, Level: 36, MoveIDs: []int{247, 60, 345, 109}}}},
+		{Name: "Byron", Badge: "Mine Badge", Specialty: "steel", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 82, Level: 37, MoveIDs: []int{430, 85, 161, 319}},
+			{ID: 208, Level: 38, MoveIDs: []int{89, 430, 423, 201}},
+			{ID: 411, Level: 41, MoveIDs: []int{442, 246, 182, 368}}}},
+		{Name: "Candice", Badge: "Icicle Badge", Specialty: "ice", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 215, Level: 38, MoveIDs: []int{420, 163, 185, 332}},
+			{ID: 221, Level: 38, MoveIDs: []int{89, 419, 31}},
+			{ID: 460, Level: 40, MoveIDs: []int{452, 420, 352, 419}},
+			{ID: 478, Level: 42, MoveIDs: []int{59, 104, 94, 247}}}},
+		{Name: "Volkner", Badge: "Beacon Badge", Specialty: "electric", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 26, Level: 46, MoveIDs: []int{85, 98, 411, 324}},
+			{ID: 424, Level: 47, MoveIDs: []int{458, 252, 86, 226}},
+			{ID: 224, Level: 47, MoveIDs: []int{451, 190, 53, 58}},
+			{ID: 405, Level: 49, MoveIDs: []int{422, 242, 423, 424}}}}},
+	UnovaRegion: {
+		{Name: "Cilan", Badge: "Trio Badge", Specialty: "grass", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 506, Level: 12, MoveIDs: []int{33, 44, 526}},
+			{ID: 511, Level: 14, MoveIDs: []int{10, 43, 22, 526}}}},
+		{Name: "Lenora", Badge: "Basic Badge", Specialty: "normal", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 507, Level: 18, MoveIDs: []int{44, 43, 36}},
+			{ID: 505, Level: 20, MoveIDs: []int{44, 514, 95}}}},
+		{Name: "Burgh", Badge: "Insect Badge", Specialty: "bug", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 544, Level: 21, MoveIDs: []int{40, 228, 103, 522}},
+			{ID: 557, Level: 21, MoveIDs: []int{479, 522, 28}},
+			{ID: 542, Level: 23, MoveIDs: []int{75, 522, 81}}}},
+		{Name: "Elesa", Badge: "Bolt Badge", Specialty: "electric", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 587, Level: 25, MoveIDs: []int{521, 98, 332, 104}},
+			{ID: 587, Level: 25, MoveIDs: []int{521, 98, 332, 104}},
+			{ID: 523, Level: 27, MoveIDs: []int{488, 521, 98, 228}}}},
+		{Name: "Clay", Badge: "Quake Badge", Specialty: "ground", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 552, Level: 29, MoveIDs: []int{523, 259, 372}},
+			{ID: 536, Level: 29, MoveIDs: []int{523, 61, 48, 392}},
+			{ID: 530, Level: 31, MoveIDs: []int{523, 157, 468, 232}}}},
+		{Name: "Skyla", Badge: "Jet Badge", Specialty: "flying", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 528, Level: 33, MoveIDs: []int{531, 512, 213}},
+			{ID: 521, Level: 33, MoveIDs: []int{403, 98, 43}},
+			{ID: 581, Level: 35, MoveIDs: []int{403, 61, 332, 355}}}},
+		{Name: "Brycen", Badge: "Freeze Badge", Specialty: "ice", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 583, Level: 37, MoveIDs: []int{524, 429, 310}},
+			{ID: 615, Level: 37, MoveIDs: []int{524, 229, 115, 62}},
+			{ID: 614, Level: 39, MoveIDs: []int{207, 362, 163, 524}}}},
+		{Name: "Drayden", Badge: "Legend Badge", Specialty: "dragon", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 611, Level: 41, MoveIDs: []int{349, 525, 372, 530}},
+			{ID: 621, Level: 41, MoveIDs: []int{525, 279, 514, 44}},
+			{ID: 612, Level: 43, MoveIDs: []int{525, 530, 14, 163}}}}},
+	KalosRegion: {
+		{Name: "Viola", Badge: "Bug Badge", Specialty: "bug", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 283, Level: 10, MoveIDs: []int{98, 145}},
+			{ID: 666, Level: 12, MoveIDs: []int{33, 106, 611}}}},
+		{Name: "Grant", Badge: "Cliff Badge", Specialty: "rock", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 698, Level: 25, MoveIDs: []int{86, 317, 62, 36}},
+			{ID: 696, Level: 25, MoveIDs: []int{317, 44, 23}}}},
+		{Name: "Korrina", Badge: "Rumble Badge", Specialty: "fighting", Strategy: GreedyBattleStrategy, Team: []GymLeaderPokemon{
+			{ID: 619, Level: 29, MoveIDs: []int{612, 252, 98}},
+			{ID: 67, Level: 28, MoveIDs: []int{612, 43, 2}},
+			{ID: 701, Level: 32, MoveIDs: []int{612, 560, 468}}}},
+		{Name: "Ramos", Badge: "Plant Badge", Specialty: "grass", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 189, Level: 30, MoveIDs: []int{512, 73, 79}},
+			{ID: 70, Level: 31, MoveIDs: []int{447, 51, 77}},
+			{ID: 673, Level: 34, MoveIDs: []int{447, 36, 73, 523}}}},
+		{Name: "Clemont", Badge: "Voltage Badge", Specialty: "electric", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 587, Level: 35, MoveIDs: []int{521, 98, 332}},
+			{ID: 82, Level: 35, MoveIDs: []int{85, 429, 486}},
+			{ID: 695, Level: 37, MoveIDs: []int{570, 98, 86, 447}}}},
+		{Name: "Valerie", Badge: "Fairy Badge", Specialty: "fairy", Strategy: GreedyBattleStrategy, Items: []BagEntry{{Item: SuperPotionItem, Count: 1}}, Team: []GymLeaderPokemon{
+			{ID: 303, Level: 38, MoveIDs: []int{242, 584, 442}},
+			{ID: 122, Level: 39, MoveIDs: []int{60, 115, 113, 605}},
+			{ID: 700, Level: 42, MoveIDs: []int{577, 98, 129}}}},
+		{Name: "Olympia", Badge: "Psychic Badge", Specialty: "psychic", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 561, Level: 44, MoveIDs: []int{403, 94, 115, 113}},
+			{ID: 199, Level: 45, MoveIDs: []int{94, 408, 281}},
+			{ID: 678, Level: 48, MoveIDs: []int{94, 252, 113}}}},
+		{Name: "Wulfric", Badge: "Iceberg Badge", Specialty: "ice", Strategy: MinimaxBattleStrategy, Items: []BagEntry{{Item: HyperPotionItem, Count: 2}}, Team: []GymLeaderPokemon{
+			{ID: 460, Level: 56, MoveIDs: []int{58, 452, 420}},
+			{ID: 615, Level: 55, MoveIDs: []int{58, 573, 115}},
+			{ID: 713, Level: 59, MoveIDs: []int{419, 242, 360, 89}}}}}}
+
+// GymLeaders returns the gym leaders of the region in the order trainers
+// have to beat them.
+func GymLeaders(r Region) []GymLeader {
+	return gymLeaders[r]
+}
+
+// NextGymLeader returns the gym leader the trainer has to beat next to earn
+// another badge in the region. It returns false if the trainer already has
+// every badge in the region.
+func NextGymLeader(t *Trainer, r Region) (GymLeader, bool) {
+	leaders := gymLeaders[r]
+	badges := t.Badges(r)
+	if badges >= len(leaders) {
+		return GymLeader{}, false
+	}
+	return leaders[badges], true
+}
@@ -0,0 +1,297 @@
+package pkmn
+
+import "github.com/pkg/errors"
+
+// Item is an item that a trainer can carry in their bag or give to a Pokemon
+// to hold.
+type Item int
+
+const (
+	NoItem Item = iota
+	PotionItem
+	SuperPotionItem
+	HyperPotionItem
+	AntidoteItem
+	ParalyzeHealItem
+	AwakeningItem
+	BurnHealItem
+	IceHealItem
+	FullHealItem
+	PokeBallItem
+	GreatBallItem
+	UltraBallItem
+	LeftoversItem
+	OranBerryItem
+	ChoiceBandItem
+	NetBallItem
+	QuickBallItem
+	DuskBallItem
+	FireStoneItem
+	WaterStoneItem
+	ThunderStoneItem
+	LeafStoneItem
+	MoonStoneItem
+	SunStoneItem
+	ShinyStoneItem
+	DuskStoneItem
+	DawnStoneItem
+	IceStoneItem
+	MetalCoatItem
+	KingsRockItem
+	DragonScaleItem
+	UpGradeItem
+	ExpShareItem
+)
+
+// ItemCategory describes how an item is used.
+type ItemCategory int
+
+const (
+	_ ItemCategory = iota
+	// MedicineItemCategory items are used on a Pokemon in the party to heal
+	// it.
+	MedicineItemCategory
+	// BallItemCategory items are thrown at wild Pokemon to catch them.
+	BallItemCategory
+	// HeldItemCategory items do nothing in the bag, but have an effect in
+	// battle when a Pokemon holds them.
+	HeldItemCategory
+	// EvolutionItemCategory items are used on a Pokemon in the party to make
+	// it evolve.
+	EvolutionItemCategory
+)
+
+// itemInfo contains information on the effects of a single item.
+type itemInfo struct {
+	name     string
+	category ItemCategory
+	// healing is the amount of HP a medicine restores.
+	healing int
+	// cures is the ailment a medicine cures.
+	cures Ailment
+	// curesAll is true if a medicine cures any ailment.
+	curesAll bool
+}
+
+var items = map[Item]itemInfo{
+	PotionItem:       {name: "potion", category: MedicineItemCategory, healing: 20},
+	SuperPotionItem:  {name: "super-potion", category: MedicineItemCategory, healing: 50},
+	HyperPotionItem:  {name: "hyper-potion", category: MedicineItemCategory, healing: 200},
+	AntidoteItem:     {name: "antidote", category: MedicineItemCategory, cures: PoisonAilment},
+	ParalyzeHealItem: {name: "paralyze-heal", category: MedicineItemCategory, cures: ParalysisAilment},
+	AwakeningItem:    {name: "awakening", category: MedicineItemCategory, cures: SleepAilment},
+	BurnHealItem:     {name: "burn-heal", category: MedicineItemCategory, cures: BurnAilment},
+	IceHealItem:      {name: "ice-heal", category: MedicineItemCategory, cures: FreezeAilment},
+	FullHealItem:     {name: "full-heal", category: MedicineItemCategory, curesAll: true},
+	PokeBallItem:     {name: "poke-ball", category: BallItemCategory},
+	GreatBallItem:    {name: "great-ball", category: BallItemCategory},
+	UltraBallItem:    {name: "ultra-ball", category: BallItemCategory},
+	NetBallItem:      {name: "net-ball", category: BallItemCategory},
+	QuickBallItem:    {name: "quick-ball", category: BallItemCategory},
+	DuskBallItem:     {name: "dusk-ball", category: BallItemCategory},
+	LeftoversItem:    {name: "leftovers", category: HeldItemCategory},
+	OranBerryItem:    {name: "oran-berry", category: HeldItemCategory},
+	ChoiceBandItem:   {name: "choice-band", category: HeldItemCategory},
+	MetalCoatItem:    {name: "metal-coat", category: HeldItemCategory},
+	KingsRockItem:    {name: "kings-rock", category: HeldItemCategory},
+	DragonScaleItem:  {name: "dragon-scale", category: HeldItemCategory},
+	UpGradeItem:      {name: "up-grade", category: HeldItemCategory},
+	ExpShareItem:     {name: "exp-share", category: HeldItemCategory},
+	FireStoneItem:    {name: "fire-stone", category: EvolutionItemCategory},
+	WaterStoneItem:   {name: "water-stone", category: EvolutionItemCategory},
+	ThunderStoneItem: {name: "thunder-stone", category: EvolutionItemCategory},
+	LeafStoneItem:    {name: "leaf-stone", category: EvolutionItemCategory},
+	MoonStoneItem:    {name: "moon-stone", category: EvolutionItemCategory},
+	SunStoneItem:     {name: "sun-stone", category: EvolutionItemCategory},
+	ShinyStoneItem:   {name: "shiny-stone", category: EvolutionItemCategory},
+	DuskStoneItem:    {name: "dusk-stone", category: EvolutionItemCategory},
+	DawnStoneItem:    {name: "dawn-stone", category: EvolutionItemCategory},
+	IceStoneItem:     {name: "ice-stone", category: EvolutionItemCategory}}
+
+// NameToItem returns the item with the given name, or false if no such item
+// exists. Item names are lowercase and use hyphens instead of spaces, like
+// "great-ball".
+func NameToItem(name string) (Item, bool) {
+	for item, info := range items {
+		if info.name == name {
+			return item, true
+		}
+	}
+	return NoItem, false
+}
+
+// Name returns the name of the item, or an empty string if the item does not
+// exist.
+func (item Item) Name() string {
+	return items[item].name
+}
+
+// Category returns the category of the item.
+func (item Item) Category() ItemCategory {
+	return items[item].category
+}
+
+// Healing returns the amount of HP the item restores when used as medicine.
+func (item Item) Healing() int {
+	return items[item].healing
+}
+
+// BagEntry is a single kind of item in a trainer's bag and how many of it the
+// trainer has.
+type BagEntry struct {
+	Item  Item
+	Count int
+}
+
+// ItemCount returns how many of the given item the trainer has in their bag.
+func (t *Trainer) ItemCount(item Item) int {
+	for _, entry := range t.Bag {
+		if entry.Item == item {
+			return entry.Count
+		}
+	}
+	return 0
+}
+
+// AddItem puts the given amount of the item in the trainer's bag.
+func (t *Trainer) AddItem(item Item, count int) {
+	for i := range t.Bag {
+		if t.Bag[i].Item == item {
+			t.Bag[i].Count += count
+			return
+		}
+	}
+	t.Bag = append(t.Bag, BagEntry{Item: item, Count: count})
+}
+
+// TakeItem removes one of the item from the trainer's bag. An error is
+// returned if the trainer doesn't have any of that item.
+func (t *Trainer) TakeItem(item Item) error {
+	for i := range t.Bag {
+		if t.Bag[i].Item == item && t.Bag[i].Count > 0 {
+			t.Bag[i].Count--
+			if t.Bag[i].Count == 0 {
+				// Remove empty entries so the bag only shows items the
+				// trainer actually has
+				t.Bag = append(t.Bag[:i], t.Bag[i+1:]...)
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("attempt to take a %v from a bag without one", item.Name())
+}
+
+// ItemReport describes what happened when an item was used or a held item
+// activated.
+type ItemReport struct {
+	// Activated is true if the item had any effect. If it is false, the rest
+	// of the report should be ignored.
+	Activated bool
+	// Item is the item that was used.
+	Item    Item
+	Healing int
+	Cured   bool
+	// Consumed is true if a held item was used up.
+	Consumed bool
+}
+
+// UseMedicine uses the medicine on the Pokemon, healing it or curing its
+// ailment. The report will not be marked as activated if the medicine would
+// have had no effect, in which case the medicine should not be taken from the
+// bag.
+func UseMedicine(item Item, p *Pokemon, pBI *PokemonBattleInfo) (ItemReport, error) {
+	info, ok := items[item]
+	if !ok || info.category != MedicineItemCategory {
+		return ItemReport{}, errors.Errorf("attempt to use %v as medicine", item.Name())
+	}
+
+	report := ItemReport{Item: item}
+
+	if pBI.CurrHP <= 0 {
+		// Medicine can't bring back fainted Pokemon
+		return report, nil
+	}
+
+	// Heal the Pokemon
+	if info.healing > 0 {
+		maxHP := CalcIBHP(*p, *pBI)
+		healing := info.healing
+		if pBI.CurrHP+healing > maxHP {
+			healing = maxHP - pBI.CurrHP
+		}
+		pBI.CurrHP += healing
+		report.Healing = healing
+	}
+
+	// Cure the Pokemon's ailment
+	if pBI.Ailment != NoAilment && (info.curesAll || info.cures == pBI.Ailment) {
+		pBI.Ailment = NoAilment
+		report.Cured = true
+	}
+
+	report.Activated = report.Healing > 0 || report.Cured
+	return report, nil
+}
+
+// heldItemDamageModifier returns the multiplier the user's held item applies
+// to the damage of the move.
+func heldItemDamageModifier(user *Pokemon, move Move) float64 {
+	if user.HeldItem == ChoiceBandItem && move.DamageClass == PhysicalDamageClass {
+		return 1.5
+	}
+	return 1.0
+}
+
+// runDamagedHeldItem applies the effects of the holder's held item after the
+// holder takes damage. An Oran Berry restores 10 HP once the holder is at or
+// below half of its max HP.
+func runDamagedHeldItem(holder *Pokemon, holderBI *PokemonBattleInfo) ItemReport {
+	if holder.HeldItem != OranBerryItem || holderBI.CurrHP <= 0 {
+		return ItemReport{}
+	}
+
+	maxHP := CalcIBHP(*holder, *holderBI)
+	if holderBI.CurrHP*2 > maxHP {
+		return ItemReport{}
+	}
+
+	healing := 10
+	if holderBI.CurrHP+healing > maxHP {
+		healing = maxHP - holderBI.CurrHP
+	}
+	holderBI.CurrHP += healing
+	holder.HeldItem = NoItem
+
+	return ItemReport{
+		Activated: true,
+		Item:      OranBerryItem,
+		Healing:   healing,
+		Consumed:  true}
+}
+
+// RunEndOfTurnHeldItem applies the end of turn effects of the holder's held
+// item. Leftovers restore a sixteenth of the holder's max HP.
+func RunEndOfTurnHeldItem(holder *Pokemon, holderBI *PokemonBattleInfo) ItemReport {
+	if holder.HeldItem != LeftoversItem || holderBI.CurrHP <= 0 {
+		return ItemReport{}
+	}
+
+	maxHP := CalcIBHP(*holder, *holderBI)
+	healing := maxHP / 16
+	if healing < 1 {
+		healing = 1
+	}
+	if holderBI.CurrHP+healing > maxHP {
+		healing = maxHP - holderBI.CurrHP
+	}
+	if healing == 0 {
+		// The holder is already at full HP
+		return ItemReport{}
+	}
+	holderBI.CurrHP += healing
+
+	return ItemReport{
+		Activated: true,
+		Item:      LeftoversItem,
+		Healing:   healing}
+}
@@ -1,28 +1,298 @@
 package pkmn
 
-import "math"
+import (
+	"math"
 
-func (pkmn *Pokemon) LevelUp(learnableMoves map[int][]int) {
+	"github.com/pkg/errors"
+)
 
+// MaxLevel is the highest level a Pokemon can reach.
+const MaxLevel = 100
+
+// LevelUpEventType is the kind of thing that happened while a Pokemon was
+// leveling up.
+type LevelUpEventType int
+
+const (
+	_ LevelUpEventType = iota
+	// LevelGainedEventType events happen when the Pokemon reaches a new
+	// level.
+	LevelGainedEventType
+	// MoveLearnedEventType events happen when the Pokemon learns a new move
+	// into an empty move slot.
+	MoveLearnedEventType
+	// MoveConflictEventType events happen when the Pokemon wants to learn a
+	// move but has no empty move slots. The trainer has to decide whether to
+	// replace a move or give up on the new one before leveling up can
+	// continue.
+	MoveConflictEventType
+	// EvolutionPendingEventType events happen when the Pokemon is ready to
+	// evolve. The trainer has to decide whether or not to let it.
+	EvolutionPendingEventType
+)
+
+// StatChanges is how much each of a Pokemon's stats changed by.
+type StatChanges struct {
+	HP        int
+	Attack    int
+	Defense   int
+	SpAttack  int
+	SpDefense int
+	Speed     int
+}
+
+// statChanges returns how much each stat of the Pokemon grew from before to
+// after.
+func statChanges(before, after Pokemon) StatChanges {
+	return StatChanges{
+		HP:        CalcOOBHP(after.HP, after) - CalcOOBHP(before.HP, before),
+		Attack:    CalcOOBStat(after, AttackStatType) - CalcOOBStat(before, AttackStatType),
+		Defense:   CalcOOBStat(after, DefenseStatType) - CalcOOBStat(before, DefenseStatType),
+		SpAttack:  CalcOOBStat(after, SpecialAttackStatType) - CalcOOBStat(before, SpecialAttackStatType),
+		SpDefense: CalcOOBStat(after, SpecialDefenseStatType) - CalcOOBStat(before, SpecialDefenseStatType),
+		Speed:     CalcOOBStat(after, SpeedStatType) - CalcOOBStat(before, SpeedStatType)}
+}
+
+// LevelUpEvent is something that happened while a Pokemon was leveling up.
+type LevelUpEvent struct {
+	Type LevelUpEventType
+	// Level is the level the Pokemon reached, for level gained events.
+	Level int
+	// StatChanges is how much the Pokemon's stats grew, for level gained
+	// events.
+	StatChanges StatChanges
+	// MoveID is the ID of the move that was learned or that the Pokemon wants
+	// to learn, for move learned and move conflict events.
+	MoveID int
+	// Evolution is the evolution the Pokemon is ready for, for evolution
+	// pending events.
+	Evolution Evolution
+}
+
+// LevelUp levels up the Pokemon as far as its experience allows, teaching it
+// the moves it learns on the way from the given map of levels to move IDs.
+// Leveling up stops early if the Pokemon wants to learn a move with no move
+// slots free, and can be continued by calling LevelUp again once the conflict
+// is resolved with LearnPendingMove or GiveUpPendingMove. If the Pokemon
+// gained a level, it's checked against the given evolutions. An evolution
+// found before a move conflict is announced once leveling up finishes. The
+// leveled up Pokemon is returned along with everything that happened to it,
+// in order.
+func LevelUp(p Pokemon, learnableMoves map[int][]int, evolutions []Evolution, clock Clock) (Pokemon, []LevelUpEvent) {
+	var events []LevelUpEvent
+
+	// Don't share the pending moves with the original Pokemon
+	p.PendingMoves = append([]int(nil), p.PendingMoves...)
+
+	var leveled, conflict bool
+	for {
+		// Learn the moves left over from earlier levels first
+		p, events, conflict = learnPendingMoves(p, events)
+		if conflict || !p.ReadyToLevelUp() {
+			break
+		}
+
+		before := p
+		p.Level++
+		leveled = true
+		// Battling together brings the Pokemon closer to its trainer
+		p.RaiseFriendship(p.LevelUpFriendship())
+		events = append(events, LevelUpEvent{
+			Type:        LevelGainedEventType,
+			Level:       p.Level,
+			StatChanges: statChanges(before, p)})
+
+		p.PendingMoves = append(p.PendingMoves, learnableMoves[p.Level]...)
+	}
+
+	// Only a new level can make the Pokemon ready to evolve
+	evo := Evolution{SpeciesID: p.PendingEvolution, Trigger: LevelUpEvolutionTrigger}
+	if leveled && p.PendingEvolution == 0 {
+		if found, ok := LevelEvolution(p, evolutions, clock); ok {
+			evo = found
+			p.PendingEvolution = evo.SpeciesID
+		}
+	}
+
+	// The evolution waits until the move conflict is resolved
+	if p.PendingEvolution != 0 && !conflict {
+		events = append(events, LevelUpEvent{
+			Type:      EvolutionPendingEventType,
+			Evolution: evo})
+	}
+
+	return p, events
+}
+
+// learnPendingMoves teaches the Pokemon its pending moves until it runs out of
+// move slots. True is returned if a move couldn't be learned because the
+// Pokemon has no free move slots.
+func learnPendingMoves(p Pokemon, events []LevelUpEvent) (Pokemon, []LevelUpEvent, bool) {
+	for len(p.PendingMoves) > 0 {
+		moveID := p.PendingMoves[0]
+		if p.KnowsMove(moveID) {
+			// There's no reason to learn a move twice
+			p.PendingMoves = p.PendingMoves[1:]
+			continue
+		}
+		if p.MoveCount() >= 4 {
+			events = append(events, LevelUpEvent{
+				Type:   MoveConflictEventType,
+				MoveID: moveID})
+			return p, events, true
+		}
+
+		p.LearnMove(moveID)
+		p.PendingMoves = p.PendingMoves[1:]
+		events = append(events, LevelUpEvent{
+			Type:   MoveLearnedEventType,
+			MoveID: moveID})
+	}
+
+	return p, events, false
+}
+
+// PendingMove returns the ID of the move the Pokemon is waiting to learn once
+// its trainer makes space for it, or false if there is none.
+func (pkmn *Pokemon) PendingMove() (int, bool) {
+	if len(pkmn.PendingMoves) == 0 {
+		return 0, false
+	}
+	return pkmn.PendingMoves[0], true
 }
 
-// Experience returns the experience gained by defeating the given Pokemon.
-func Experience(faintedPkmn Pokemon, wild bool) int {
+// LearnPendingMove replaces the move in the given slot (1-4) with the move
+// the Pokemon is waiting to learn.
+func (pkmn *Pokemon) LearnPendingMove(slot int) error {
+	moveID, ok := pkmn.PendingMove()
+	if !ok {
+		return errors.New("attempt to learn a pending move when there is none")
+	}
+	err := pkmn.ReplaceMove(slot, moveID)
+	if err != nil {
+		return err
+	}
+	pkmn.PendingMoves = pkmn.PendingMoves[1:]
+	return nil
+}
+
+// GiveUpPendingMove makes the Pokemon give up on learning the move it is
+// waiting to learn.
+func (pkmn *Pokemon) GiveUpPendingMove() {
+	if len(pkmn.PendingMoves) > 0 {
+		pkmn.PendingMoves = pkmn.PendingMoves[1:]
+	}
+}
+
+// Experience returns the experience a Pokemon at the given level gains by
+// defeating the given Pokemon, using the formula from Generation V onward.
+// Pokemon at a lower level than the defeated one gain more experience, and
+// those at a higher level gain less. Defeating a trainer's Pokemon is worth
+// more than defeating a wild one. The experience is split between the given
+// number of shares.
+func Experience(faintedPkmn Pokemon, level int, wild bool, shares int) int {
 	var wildMod float64
 	if wild {
 		wildMod = 1.0
 	} else {
 		wildMod = 1.5
 	}
+	if shares < 1 {
+		shares = 1
+	}
 
 	baseExp := float64(faintedPkmn.BaseExperience)
-	level := float64(faintedPkmn.Level)
+	faintedLevel := float64(faintedPkmn.Level)
+	recipientLevel := float64(level)
+
+	levelMod := math.Pow((2.0*faintedLevel+10.0)/(faintedLevel+recipientLevel+10.0), 2.5)
 
-	return int((baseExp * wildMod * level) / (7.0))
+	return int((wildMod*baseExp*faintedLevel)/(5.0*float64(shares))*levelMod) + 1
+}
+
+// ShareExperience splits the experience for defeating the given Pokemon
+// between the Pokemon in the victor's party, which are given along with their
+// battle info. Pokemon that battled against the defeated Pokemon and haven't
+// fainted share the experience. If any Pokemon that haven't fainted are
+// holding an Exp. Share, the battlers split half of it and the Exp. Share
+// holders split the other half. A Pokemon that is both gets both parts. The
+// experience for each party member is returned in party order.
+func ShareExperience(faintedPkmn Pokemon, faintedPkmnBI PokemonBattleInfo, wild bool, party []Pokemon, partyBIs []PokemonBattleInfo) []int {
+	// Find who gets a share of the experience
+	var battlers, holders int
+	isBattler := make([]bool, len(party))
+	isHolder := make([]bool, len(party))
+	for i, p := range party {
+		if partyBIs[i].CurrHP <= 0 {
+			continue
+		}
+		if faintedPkmnBI.FoughtBy(p.UUID) {
+			isBattler[i] = true
+			battlers++
+		}
+		if p.HeldItem == ExpShareItem {
+			isHolder[i] = true
+			holders++
+		}
+	}
+
+	// With an Exp. Share around, each group has twice as many shares to split
+	battlerShares, holderShares := battlers, holders
+	if holders > 0 {
+		battlerShares *= 2
+		holderShares *= 2
+	}
+
+	exp := make([]int, len(party))
+	for i, p := range party {
+		if isBattler[i] {
+			exp[i] += Experience(faintedPkmn, p.Level, wild, battlerShares)
+		}
+		if isHolder[i] {
+			exp[i] += Experience(faintedPkmn, p.Level, wild, holderShares)
+		}
+	}
+
+	return exp
+}
+
+// CurrentExperienceVersion is the version of the meaning of a Pokemon's
+// Experience. Version 0 Pokemon had one level's worth of experience too many,
+// so that they had at least the experience needed for the level above their
+// own.
+const CurrentExperienceVersion = 1
+
+// MigrateExperience moves the experience of a Pokemon saved with an older
+// version of its meaning onto the current one. The Pokemon keeps the progress
+// it made toward its next level, without going so far as to reach it.
+// Pokemon that are already on the current version are left alone.
+func (pkmn *Pokemon) MigrateExperience() {
+	if pkmn.ExperienceVersion >= CurrentExperienceVersion {
+		return
+	}
+	pkmn.ExperienceVersion = CurrentExperienceVersion
+
+	if pkmn.Level >= MaxLevel {
+		return
+	}
+
+	// Take away the extra level's worth of experience
+	curr := RequiredExperience(pkmn.GrowthRate, pkmn.Level)
+	next := RequiredExperience(pkmn.GrowthRate, pkmn.Level+1)
+	pkmn.Experience -= next - curr
+
+	if pkmn.Experience < curr {
+		pkmn.Experience = curr
+	} else if pkmn.Experience >= next {
+		pkmn.Experience = next - 1
+	}
 }
 
 // ReadyToLevelUp returns true if the Pokemon is ready to level up.
 func (pkmn *Pokemon) ReadyToLevelUp() bool {
+	if pkmn.Level >= MaxLevel {
+		return false
+	}
 	return pkmn.Experience >= RequiredExperience(pkmn.GrowthRate, pkmn.Level+1)
 }
 
@@ -31,17 +301,17 @@ func (pkmn *Pokemon) ReadyToLevelUp() bool {
 func RequiredExperience(growthRate GrowthRate, n int) int {
 	switch growthRate {
 	case ErraticGrowthRate:
-		return ErraticExp(n + 1)
+		return ErraticExp(n)
 	case FastGrowthRate:
-		return FastExp(n + 1)
+		return FastExp(n)
 	case MediumFastGrowthRate:
-		return MediumFastExp(n + 1)
+		return MediumFastExp(n)
 	case MediumSlowGrowthRate:
-		return MediumSlowExp(n + 1)
+		return MediumSlowExp(n)
 	case SlowGrowthRate:
-		return SlowExp(n + 1)
+		return SlowExp(n)
 	case FluctuatingGrowthRate:
-		return FluctuatingExp(n + 1)
+		return FluctuatingExp(n)
 	default:
 		panic("invalid growth rate")
 	}
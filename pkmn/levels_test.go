@@ -0,0 +1,157 @@
+package pkmn
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock that is stuck at one time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// noon is a Clock that is always in the day.
+var noon = fixedClock(time.Date(2016, time.May, 1, 12, 0, 0, 0, time.UTC))
+
+// levelUpEventTypes returns the types of the given events, in order.
+func levelUpEventTypes(events []LevelUpEvent) []LevelUpEventType {
+	var types []LevelUpEventType
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func sameEventTypes(a, b []LevelUpEventType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLevelUpEvolution(t *testing.T) {
+	// A level 15 Pokemon that evolves at level 16
+	evolutions := []Evolution{{SpeciesID: 2, Trigger: LevelUpEvolutionTrigger, MinLevel: 16}}
+	base := Pokemon{
+		ID:         1,
+		Level:      15,
+		GrowthRate: MediumFastGrowthRate,
+		Experience: MediumFastExp(15),
+		Move1:      1}
+
+	// Nothing happens without the experience for a new level
+	p, events := LevelUp(base, nil, evolutions, noon)
+	if len(events) != 0 || p.PendingEvolution != 0 {
+		t.Errorf("got events %v and pending evolution %v without a new level, want none",
+			levelUpEventTypes(events), p.PendingEvolution)
+	}
+
+	// A new level makes the Pokemon ready to evolve
+	base.Experience = MediumFastExp(16)
+	p, events = LevelUp(base, nil, evolutions, noon)
+	want := []LevelUpEventType{LevelGainedEventType, EvolutionPendingEventType}
+	if !sameEventTypes(levelUpEventTypes(events), want) || p.PendingEvolution != 2 {
+		t.Errorf("got events %v and pending evolution %v after a new level, want %v and 2",
+			levelUpEventTypes(events), p.PendingEvolution, want)
+	}
+
+	// Continuing to level up after evolving doesn't evolve the Pokemon again,
+	// even if the evolved form meets its own conditions already
+	p.Evolve(Pokemon{ID: 2}, nil)
+	evolved := []Evolution{{SpeciesID: 3, Trigger: LevelUpEvolutionTrigger, MinLevel: 16}}
+	p, events = LevelUp(p, nil, evolved, noon)
+	if len(events) != 0 || p.PendingEvolution != 0 {
+		t.Errorf("got events %v and pending evolution %v after evolving, want none",
+			levelUpEventTypes(events), p.PendingEvolution)
+	}
+
+	// The same goes for continuing after a cancelled evolution
+	p = base
+	p.Level = 16
+	p.CancelEvolution()
+	p, events = LevelUp(p, nil, evolutions, noon)
+	if len(events) != 0 || p.PendingEvolution != 0 {
+		t.Errorf("got events %v and pending evolution %v after cancelling, want none",
+			levelUpEventTypes(events), p.PendingEvolution)
+	}
+}
+
+func TestLevelUpEvolutionAfterMoveConflict(t *testing.T) {
+	// A Pokemon with no free move slots that learns a move as it reaches its
+	// evolution level
+	evolutions := []Evolution{{SpeciesID: 2, Trigger: LevelUpEvolutionTrigger, MinLevel: 16}}
+	learnableMoves := map[int][]int{16: {5}}
+	p := Pokemon{
+		ID:         1,
+		Level:      15,
+		GrowthRate: MediumFastGrowthRate,
+		Experience: MediumFastExp(16),
+		Move1:      1,
+		Move2:      2,
+		Move3:      3,
+		Move4:      4}
+
+	// The evolution is held back until the move conflict is resolved
+	p, events := LevelUp(p, learnableMoves, evolutions, noon)
+	want := []LevelUpEventType{LevelGainedEventType, MoveConflictEventType}
+	if !sameEventTypes(levelUpEventTypes(events), want) {
+		t.Errorf("got events %v with a move conflict, want %v", levelUpEventTypes(events), want)
+	}
+	if p.PendingEvolution != 2 {
+		t.Errorf("got pending evolution %v with a move conflict, want 2", p.PendingEvolution)
+	}
+
+	// Continuing without a new level still announces the evolution
+	p.GiveUpPendingMove()
+	p, events = LevelUp(p, learnableMoves, evolutions, noon)
+	want = []LevelUpEventType{EvolutionPendingEventType}
+	if !sameEventTypes(levelUpEventTypes(events), want) {
+		t.Errorf("got events %v after the move conflict, want %v", levelUpEventTypes(events), want)
+	}
+	if len(events) == 1 && events[0].Evolution.SpeciesID != 2 {
+		t.Errorf("got evolution into %v after the move conflict, want 2", events[0].Evolution.SpeciesID)
+	}
+}
+
+func TestMigrateExperience(t *testing.T) {
+	// Medium fast Pokemon at level 10 need 1000 experience for their level
+	// and 1331 for the next one. Before the migration, they had between 1331
+	// and 1728.
+	tests := []struct {
+		name       string
+		level      int
+		experience int
+		version    int
+		want       int
+	}{
+		{"fresh", 10, 1331, 0, 1000},
+		{"partway", 10, 1431, 0, 1100},
+		{"close to the old next level", 10, 1727, 0, 1330},
+		{"below the old minimum", 10, 1200, 0, 1000},
+		{"max level", MaxLevel, 1000000, 0, 1000000},
+		{"already migrated", 10, 1100, CurrentExperienceVersion, 1100},
+	}
+
+	for _, test := range tests {
+		p := Pokemon{
+			Level:             test.level,
+			GrowthRate:        MediumFastGrowthRate,
+			Experience:        test.experience,
+			ExperienceVersion: test.version}
+		p.MigrateExperience()
+		if p.Experience != test.want || p.ExperienceVersion != CurrentExperienceVersion {
+			t.Errorf("%v: got experience %v at version %v, want %v at version %v",
+				test.name, p.Experience, p.ExperienceVersion, test.want, CurrentExperienceVersion)
+		}
+		if p.ReadyToLevelUp() {
+			t.Errorf("%v: migrated Pokemon is ready to level up", test.name)
+		}
+	}
+}
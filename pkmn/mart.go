@@ -0,0 +1,76 @@
+package pkmn
+
+import "github.com/pkg/errors"
+
+// MaxPurchaseCount is the most of a single item that can be bought at once.
+const MaxPurchaseCount = 99
+
+// MartItem is an item sold at the Poke Mart.
+type MartItem struct {
+	Item  Item
+	Price int
+	// Badges is the number of badges, from any region, a trainer needs
+	// before the Poke Mart will sell them the item.
+	Badges int
+}
+
+// martCatalogue contains every item sold at the Poke Mart, in the order
+// they're listed. It's empty until the catalogue is loaded with
+// SetMartCatalogue.
+var martCatalogue []MartItem
+
+// ValidateMartCatalogue checks that every item in the catalogue exists, is
+// only listed once and has a positive price.
+func ValidateMartCatalogue(catalogue []MartItem) error {
+	listed := make(map[Item]bool)
+	for _, mi := range catalogue {
+		if mi.Item == NoItem || mi.Item.Name() == "" {
+			return errors.Errorf("unknown item %v in mart catalogue", int(mi.Item))
+		}
+		if listed[mi.Item] {
+			return errors.Errorf("item %v is listed in the mart catalogue more than once", mi.Item.Name())
+		}
+		listed[mi.Item] = true
+
+		if mi.Price <= 0 || mi.Price > MaxMoney {
+			return errors.Errorf("item %v has an invalid price %v", mi.Item.Name(), mi.Price)
+		}
+		if mi.Badges < 0 {
+			return errors.Errorf("item %v needs a negative number of badges %v", mi.Item.Name(), mi.Badges)
+		}
+	}
+
+	return nil
+}
+
+// SetMartCatalogue validates the given catalogue and, if it's valid, makes it
+// the list of items sold at the Poke Mart.
+func SetMartCatalogue(catalogue []MartItem) error {
+	if err := ValidateMartCatalogue(catalogue); err != nil {
+		return err
+	}
+	martCatalogue = catalogue
+	return nil
+}
+
+// MartCatalogue returns every item sold at the Poke Mart.
+func MartCatalogue() []MartItem {
+	return martCatalogue
+}
+
+// FindMartItem returns the Poke Mart listing of the given item, or false if
+// the Poke Mart doesn't sell it.
+func FindMartItem(item Item) (MartItem, bool) {
+	for _, mi := range martCatalogue {
+		if mi.Item == item {
+			return mi, true
+		}
+	}
+	return MartItem{}, false
+}
+
+// CanBuy returns true if the trainer has enough badges for the Poke Mart to
+// sell them the item.
+func (t *Trainer) CanBuy(mi MartItem) bool {
+	return t.TotalBadges() >= mi.Badges
+}
@@ -0,0 +1,108 @@
+package pkmn
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxMoney is the most money a trainer can have. Anything earned past this is
+// lost.
+const MaxMoney = 999999
+
+const (
+	// trainerPrizeBase is the prize money given per level for beating
+	// another human trainer.
+	trainerPrizeBase = 40
+	// gymLeaderPrizeBase is the prize money given per level for beating a
+	// gym leader.
+	gymLeaderPrizeBase = 100
+)
+
+// LedgerReason is the reason a trainer's balance changed.
+type LedgerReason int
+
+const (
+	_ LedgerReason = iota
+	// PrizeLedgerReason is for money won or lost in a battle.
+	PrizeLedgerReason
+	// PurchaseLedgerReason is for money spent at the Poke Mart.
+	PurchaseLedgerReason
+)
+
+// LedgerEntry records a single change to a trainer's balance so that every
+// balance can be audited.
+type LedgerEntry struct {
+	TrainerUUID string
+	// Amount is how much the balance changed by. Money spent is negative.
+	Amount int
+	// Balance is the trainer's balance after the change.
+	Balance int
+	Reason  LedgerReason
+	// Detail describes what the money was for, like the item bought or the
+	// trainer beaten.
+	Detail string
+	Time   time.Time
+}
+
+// ErrNotEnoughMoney is returned when a trainer tries to spend more money than
+// they have.
+var ErrNotEnoughMoney = errors.New("not enough money")
+
+// PrizeMoney returns the money a trainer earns for beating the given trainer,
+// whose strongest Pokemon is at the given level. Only other human trainers and
+// gym leaders give out prize money. Human trainers pay the prize out of their
+// own pocket, so it's never more than they have.
+func PrizeMoney(loser *Trainer, level int) int {
+	switch loser.Type {
+	case HumanTrainerType:
+		prize := trainerPrizeBase * level
+		if prize > loser.Money {
+			prize = loser.Money
+		}
+		return prize
+	case GymLeaderTrainerType:
+		return gymLeaderPrizeBase * level
+	default:
+		return 0
+	}
+}
+
+// Earn adds the amount to the trainer's balance, up to MaxMoney, and returns
+// the ledger entry recording the change.
+func (t *Trainer) Earn(amount int, reason LedgerReason, detail string, when time.Time) LedgerEntry {
+	before := t.Money
+	t.Money += amount
+	if t.Money > MaxMoney {
+		t.Money = MaxMoney
+	}
+
+	return LedgerEntry{
+		TrainerUUID: t.UUID,
+		Amount:      t.Money - before,
+		Balance:     t.Money,
+		Reason:      reason,
+		Detail:      detail,
+		Time:        when}
+}
+
+// Spend takes the amount from the trainer's balance and returns the ledger
+// entry recording the change. ErrNotEnoughMoney is returned and the balance is
+// left alone if the trainer can't afford it.
+func (t *Trainer) Spend(amount int, reason LedgerReason, detail string, when time.Time) (LedgerEntry, error) {
+	if amount < 0 {
+		return LedgerEntry{}, errors.Errorf("attempt to spend a negative amount %v", amount)
+	}
+	if amount > t.Money {
+		return LedgerEntry{}, ErrNotEnoughMoney
+	}
+	t.Money -= amount
+
+	return LedgerEntry{
+		TrainerUUID: t.UUID,
+		Amount:      -amount,
+		Balance:     t.Money,
+		Reason:      reason,
+		Detail:      detail,
+		Time:        when}, nil
+}
@@ -0,0 +1,34 @@
+package pkmn
+
+import "testing"
+
+func TestPrizeMoney(t *testing.T) {
+	tests := []struct {
+		name  string
+		loser Trainer
+		level int
+		want  int
+	}{
+		{"human", Trainer{Type: HumanTrainerType, Money: 10000}, 20, 800},
+		{"human short on money", Trainer{Type: HumanTrainerType, Money: 500}, 20, 500},
+		{"broke human", Trainer{Type: HumanTrainerType}, 20, 0},
+		{"gym leader", Trainer{Type: GymLeaderTrainerType}, 20, 2000},
+		{"wild", Trainer{Type: WildTrainerType}, 20, 0},
+	}
+
+	for _, test := range tests {
+		if got := PrizeMoney(&test.loser, test.level); got != test.want {
+			t.Errorf("%v: PrizeMoney(%v) = %v, want %v", test.name, test.level, got, test.want)
+		}
+	}
+}
+
+func TestEarnAtMaxMoney(t *testing.T) {
+	tr := Trainer{Money: MaxMoney - 100}
+	if entry := tr.Earn(500, PrizeLedgerReason, "", noon.Now()); entry.Amount != 100 || tr.Money != MaxMoney {
+		t.Errorf("earning near the cap gave %v for a balance of %v, want 100 and %v", entry.Amount, tr.Money, MaxMoney)
+	}
+	if entry := tr.Earn(500, PrizeLedgerReason, "", noon.Now()); entry.Amount != 0 || tr.Money != MaxMoney {
+		t.Errorf("earning at the cap gave %v for a balance of %v, want 0 and %v", entry.Amount, tr.Money, MaxMoney)
+	}
+}
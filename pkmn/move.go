@@ -39,6 +39,7 @@ const (
 	SpeedStatType
 	EvasionStatType
 	AccuracyStatType
+	HPStatType
 )
 
 type MoveTarget int
@@ -98,12 +99,37 @@ type MoveReport struct {
 	Asleep           bool
 	Frozen           bool
 	Burned           bool
+	TargetAbility    AbilityReport
+	TargetHeldItem   ItemReport
+	// WeatherStarted is the weather the move started, or NoWeather if it
+	// didn't start any.
+	WeatherStarted Weather
+	// SideConditionStarted is the side condition the move created, or
+	// NoSideCondition if it didn't create any. Entry hazards are laid on the
+	// target's side and all other conditions on the user's side.
+	SideConditionStarted SideCondition
+	// Charging is true if the user spent the turn charging up a two turn
+	// move.
+	Charging bool
+	// Recharging is true if the user spent the turn recharging from a move
+	// like Hyper Beam.
+	Recharging bool
+	// Protected is true if the user protected itself, and ProtectFailed is
+	// true if it tried to but failed.
+	Protected     bool
+	ProtectFailed bool
+	// TargetProtected is true if the target protected itself from the move.
+	TargetProtected bool
+	// UserFatigued is true if the user's rampage ended and left it confused.
+	UserFatigued bool
 }
 
 // CalcMoveOrder calculates which move should go first based on the move
 // itself and the user of the move. The function returns 1 if Pokemon 1 goes
-// first, or 2 if Pokemon 2 goes first.
-func CalcMoveOrder(pkmn1, pkmn2 Pokemon, pkmnBI1, pkmnBI2 PokemonBattleInfo, move1, move2 Move) int {
+// first, or 2 if Pokemon 2 goes first. The weather is used to find the
+// effects of the Pokemon's abilities on their speed, and the sides are the
+// battle info of the trainers that own each Pokemon.
+func CalcMoveOrder(pkmn1, pkmn2 Pokemon, pkmnBI1, pkmnBI2 PokemonBattleInfo, side1, side2 TrainerBattleInfo, move1, move2 Move, weather Weather) int {
 	// Check if the moves have different priority and find move order based
 	// on that if possible. Moves with a higher priority go before moves
 	// with a lower priority
@@ -115,9 +141,11 @@ func CalcMoveOrder(pkmn1, pkmn2 Pokemon, pkmnBI1, pkmnBI2 PokemonBattleInfo, mov
 
 	// Check if the Pokemon have different speeds and find the move order
 	// based off of that if possible. Pokemon with higher speeds go first.
-	if CalcIBSpeed(pkmn1, pkmnBI1) > CalcIBSpeed(pkmn2, pkmnBI2) {
+	speed1 := CalcEffectiveSpeed(pkmn1, pkmnBI1, side1, weather)
+	speed2 := CalcEffectiveSpeed(pkmn2, pkmnBI2, side2, weather)
+	if speed1 > speed2 {
 		return 1
-	} else if CalcIBSpeed(pkmn1, pkmnBI1) < CalcIBSpeed(pkmn2, pkmnBI2) {
+	} else if speed1 < speed2 {
 		return 2
 	}
 
@@ -140,53 +168,56 @@ func critChance(critRate int) float64 {
 	}
 }
 
-// calcDamage calculates the damage the target will take if the user uses the
-// given move. It returns the damage given, the type effectiveness (positive if
-// super effective, negative if not very effective, zero if regular), true if it
-// was a critical hit, and potentially an error.
-func calcDamage(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move Move) (int, int, bool, error) {
-	// Convert target type names to type objects
+// typeEffectiveness returns the multiplier a move of the given type gets
+// against the target because of the target's types.
+func typeEffectiveness(target *Pokemon, moveType string) (float64, error) {
 	targetType1, ok := NameToType(target.Type1)
 	if !ok {
-		return 0, 0, false, errors.New("no type found for type name '" + target.Type1 + "'")
+		return 0, errors.New("no type found for type name '" + target.Type1 + "'")
 	}
-	var targetType2 Type
+	typeEff := targetType1.Mod(moveType)
 	if target.Type2 != "" {
-		targetType2, ok = NameToType(target.Type2)
+		targetType2, ok := NameToType(target.Type2)
 		if !ok {
-			return 0, 0, false, errors.New("no type found for type name '" + target.Type2 + "'")
+			return 0, errors.New("no type found for type name '" + target.Type2 + "'")
 		}
+		typeEff *= targetType2.Mod(moveType)
 	}
+	return typeEff, nil
+}
 
-	log.Printf("Target types: %+v %+v", targetType1, targetType2)
-
+// baseDamage calculates the damage the target will take if the user uses the
+// given move in the given weather, before the critical hit multiplier and the
+// random number are applied. Whether or not the move is a critical hit is
+// needed because critical hits go through screens. The target side is the
+// battle info of the trainer that owns the target. It returns the damage and
+// the type effectiveness multiplier.
+func baseDamage(weather Weather, user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, targetSide *TrainerBattleInfo, move Move, crit bool) (float64, float64, error) {
 	// Calculate same type attack bonus
 	stab := 1.0
 	if user.Type1 == move.Type || user.Type2 == move.Type {
 		stab = 1.5
 	}
-	log.Printf("Stab: %v", stab)
 	// Calculate type effectiveness
-	typeEff := 1.0
-	typeEff *= targetType1.Mod(move.Type)
-	log.Printf("Type 1 mod: %v", typeEff)
-	if target.Type2 != "" {
-		typeEff *= targetType2.Mod(move.Type)
-		log.Printf("Type 2 mod: %v", targetType2.Mod(move.Type))
-	}
-	// Calculate critical hit effectiveness
-	crit := 1.0
-	if float64(rand.Intn(100)+1) <= critChance(move.CritRate) {
-		crit = 1.5
+	typeEff, err := typeEffectiveness(target, move.Type)
+	if err != nil {
+		return 0, 0, err
 	}
-	log.Printf("Critical hit: %v", crit)
-	// Calculate the random number
-	random := float64(rand.Intn(15)+85) / 100.0
-	log.Printf("Random: %v", crit)
+
+	// Calculate the effect of the user's and target's abilities
+	abilityMod := abilityDamageModifier(user, target, userBI, targetBI, move)
+
+	// Calculate the effect of the user's held item
+	itemMod := heldItemDamageModifier(user, move)
+
+	// Calculate the effect of the weather
+	weatherMod := weatherDamageModifier(weather, move)
+
+	// Calculate the effect of the screens on the target's side of the field
+	screenMod := screenDamageModifier(targetSide, move, crit)
 
 	// Calculate the modifier
-	modifier := stab * typeEff * crit * random
-	log.Printf("Modifier: %v", modifier)
+	modifier := stab * typeEff * abilityMod * itemMod * weatherMod * screenMod
 
 	// Calculate the user's special or physical attack and the target's
 	// physical or special defense
@@ -196,23 +227,156 @@ func calcDamage(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move
 		def = float64(CalcIBDefense(*target, *targetBI))
 	} else if move.DamageClass == SpecialDamageClass {
 		att = float64(CalcIBSpAtt(*user, *userBI))
-		def = float64(CalcIBDefense(*target, *targetBI))
+		def = float64(CalcIBSpDef(*target, *targetBI)) * weatherSpDefModifier(weather, target)
 	}
-	log.Printf("Att: %v", att)
-	log.Printf("Def: %v", def)
 
 	// Calculate the damage
-	return int(((((2.0*float64(user.Level)+10)/250.0)*(att/def))*float64(move.Power) + 2.0) * modifier), int(typeEff), (crit > 1.0), nil
+	return ((((2.0*float64(user.Level)+10)/250.0)*(att/def))*float64(move.Power) + 2.0) * modifier, typeEff, nil
+}
+
+// calcDamage calculates the damage the target will take if the user uses the
+// given move in the given weather. The target side is the battle info of the
+// trainer that owns the target. It returns the damage given, the type
+// effectiveness (positive if super effective, negative if not very effective,
+// zero if regular), true if it was a critical hit, and potentially an error.
+func calcDamage(weather Weather, user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, targetSide *TrainerBattleInfo, move Move) (int, int, bool, error) {
+	// Calculate critical hit effectiveness
+	crit := 1.0
+	if float64(rand.Intn(100)+1) <= critChance(move.CritRate) {
+		crit = 1.5
+	}
+	log.Printf("Critical hit: %v", crit)
+	// Calculate the random number
+	random := float64(rand.Intn(15)+85) / 100.0
+	log.Printf("Random: %v", random)
+
+	damage, typeEff, err := baseDamage(weather, user, target, userBI, targetBI, targetSide, move, crit > 1.0)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return int(damage * crit * random), int(typeEff), (crit > 1.0), nil
+}
+
+// ExpectedDamage estimates the damage the target will take on average if the
+// user uses the given move in the given battle. Unlike the real damage
+// calculation it has no random elements, which makes it useful for deciding
+// which move is best. Critical hits are not accounted for, and the damage is
+// scaled down by the chance of the move missing.
+func ExpectedDamage(b *Battle, user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, targetSide *TrainerBattleInfo, move Move) (float64, error) {
+	if move.DamageClass == StatusDamageClass || move.Power <= 0 {
+		return 0, nil
+	}
+
+	damage, _, err := baseDamage(b.Weather, user, target, userBI, targetBI, targetSide, move, false)
+	if err != nil {
+		return 0, err
+	}
+	// The average of the random number used in the real calculation
+	damage *= 0.92
+
+	// Moves with no accuracy never miss
+	if move.Accuracy > 0 {
+		damage *= float64(move.Accuracy) / 100.0
+	}
+	// Moves that take up two turns only deal half as much damage per turn
+	_, charge := chargeMoves[move.Name]
+	if charge || rechargeMoves[move.Name] {
+		damage /= 2
+	}
+	return damage, nil
+}
+
+// targetsField returns true if the move affects the field instead of a single
+// Pokemon, like weather moves and entry hazards.
+func targetsField(move Move) bool {
+	_, weather := weatherMoves[move.Name]
+	_, sideCondition := sideConditionMoves[move.Name]
+	return weather || sideCondition
 }
 
-// RunMove uses the move on the target.
-func RunMove(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move Move) (MoveReport, error) {
+// RunMove uses the move on the target in the given battle. The sides are the
+// battle info of the trainers that own the user and the target.
+func RunMove(b *Battle, user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, userSide, targetSide *TrainerBattleInfo, move Move) (MoveReport, error) {
 	var mr MoveReport
 
+	// A Pokemon that used a move like Hyper Beam last turn has to spend this
+	// turn recharging
+	if userBI.RechargeMove != 0 {
+		userBI.RechargeMove = 0
+		mr.Recharging = true
+		mr.Effectiveness = 1.0
+		return mr, nil
+	}
+
+	// A Pokemon holding a Choice Band is locked into the first move it uses
+	if user.HeldItem == ChoiceBandItem && userBI.ChoiceLockedMove == 0 {
+		userBI.ChoiceLockedMove = move.ID
+	}
+
+	// Protect moves are less likely to work each time they're used in a row
+	if protectMoves[move.Name] {
+		if protectSucceeds(userBI.ProtectCount) {
+			userBI.Protected = true
+			userBI.ProtectCount++
+			mr.Protected = true
+		} else {
+			userBI.ProtectCount = 0
+			mr.ProtectFailed = true
+		}
+		mr.Effectiveness = 1.0
+		return mr, nil
+	}
+	userBI.ProtectCount = 0
+
+	// Two turn moves spend their first turn charging up. Solar Beam doesn't
+	// need to charge in harsh sunlight.
+	if semiInvulnerable, ok := chargeMoves[move.Name]; ok && userBI.ChargingMove != move.ID &&
+		!(move.Name == "solar-beam" && b.Weather == SunWeather) {
+		userBI.ChargingMove = move.ID
+		userBI.SemiInvulnerable = semiInvulnerable
+		mr.Charging = true
+		mr.Effectiveness = 1.0
+		return mr, nil
+	}
+	userBI.ChargingMove = 0
+	userBI.SemiInvulnerable = false
+
+	// Rampaging moves lock the user in for two or three turns, then leave it
+	// confused
+	if rampageMoves[move.Name] {
+		if userBI.RampageMove != move.ID {
+			userBI.RampageMove = move.ID
+			userBI.RampageTurns = 2 + rand.Intn(2)
+		}
+		userBI.RampageTurns--
+		if userBI.RampageTurns <= 0 {
+			userBI.RampageMove = 0
+			userBI.RampageTurns = 0
+			userBI.Confused = true
+			mr.UserFatigued = true
+		}
+	}
+
+	// Moves aimed at the target can't reach it while it's protected or out
+	// of reach. Moves that affect the whole field aren't stopped.
+	if move.Target != SelfMoveTarget && !targetsField(move) {
+		if targetBI.Protected {
+			mr.TargetProtected = true
+			mr.Effectiveness = 1.0
+			return mr, nil
+		}
+		if targetBI.SemiInvulnerable {
+			mr.Missed = true
+			mr.Effectiveness = 1.0
+			return mr, nil
+		}
+	}
+
 	// Moves with zero accuracy always hit, so no further calculation is needed
 	// in that case.
 	if move.Accuracy != 0 {
-		if rand.Intn(100)+1 > move.Accuracy*(CalcIBAccuracy(*user, *userBI)/CalcIBEvasion(*target, *targetBI)) {
+		if rand.Intn(100)+1 > (move.Accuracy*CalcIBAccuracy(*user, *userBI))/CalcIBEvasion(*target, *targetBI) {
 			// The move missed, so we have nothing to do
 			mr.Missed = true
 			// We don't care about effectiveness since the move missed
@@ -232,50 +396,81 @@ func RunMove(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move Mo
 
 	// Check if the move heals
 	if move.Healing > 0 {
-		// The move heals the user by the given percent
-		maxHP := CalcIBHP(*user, *userBI) * move.Healing
-		userBI.CurrHP += maxHP * move.Healing
+		// The move heals the user by the given percent of their max HP
+		maxHP := CalcIBHP(*user, *userBI)
+		healing := (maxHP * move.Healing) / 100
+		userBI.CurrHP += healing
 		if userBI.CurrHP > maxHP {
 			userBI.CurrHP = maxHP
 		}
-		mr.UserHealing = maxHP * move.Healing
+		mr.UserHealing = healing
 	}
 
 	// Check what kind of damage class the move is in
 	if move.DamageClass == StatusDamageClass {
 		// Apply all status effects onto the target
 		for _, statChange := range move.StatChanges {
+			// Stat stages can't go past their limits, so the actual change
+			// may be smaller than the one the move asks for
+			change := targetBI.ChangeStatStage(statChange.Stat, statChange.Change)
 			switch statChange.Stat {
 			case AttackStatType:
-				targetBI.AttStage += statChange.Change
-				mr.AttStageChange = statChange.Change
+				mr.AttStageChange = change
 			case DefenseStatType:
-				targetBI.DefStage += statChange.Change
-				mr.DefStageChange = statChange.Change
+				mr.DefStageChange = change
 			case SpecialAttackStatType:
-				targetBI.SpAttStage += statChange.Change
-				mr.SpAttStageChange = statChange.Change
+				mr.SpAttStageChange = change
 			case SpecialDefenseStatType:
-				targetBI.SpDefStage += statChange.Change
-				mr.SpDefStageChange = statChange.Change
+				mr.SpDefStageChange = change
 			case SpeedStatType:
-				targetBI.SpeedStage += statChange.Change
-				mr.SpeedStageChange = statChange.Change
+				mr.SpeedStageChange = change
+			}
+		}
+
+		// Start the weather if this is a weather move
+		if weather, ok := weatherMoves[move.Name]; ok && b.StartWeather(weather) {
+			mr.WeatherStarted = weather
+		}
+
+		// Create the side condition if this move creates one
+		if sc, ok := sideConditionMoves[move.Name]; ok {
+			side := userSide
+			if sc.IsHazard() {
+				side = targetSide
+			}
+			if side.AddSideCondition(sc) {
+				mr.SideConditionStarted = sc
 			}
 		}
 
 		// We don't care about type effectiveness for status moves
 		mr.Effectiveness = 1.0
 	} else {
+		// Check if the target's ability makes it immune to the move
+		if hooks := hooksOf(target); hooks.damageCalc != nil && hooks.damageCalc(target, targetBI, move, false) == 0 {
+			mr.TargetAbility = AbilityReport{
+				Activated: true,
+				Ability:   target.Ability,
+				Immune:    true}
+			// The move had no effect, so there's nothing left to do
+			mr.Effectiveness = 1.0
+			return mr, nil
+		}
+
 		// Calculate the damage done by the move
-		damage, effectiveness, crit, err := calcDamage(user, target, userBI, targetBI, move)
+		damage, effectiveness, crit, err := calcDamage(b.Weather, user, target, userBI, targetBI, targetSide, move)
 		if err != nil {
 			return MoveReport{}, err
 		}
 
+		// Let the target's ability react to the damage
+		if hooks := hooksOf(target); hooks.damageTaken != nil {
+			damage, mr.TargetAbility = hooks.damageTaken(target, user, targetBI, userBI, move, damage)
+		}
+
 		// Deal the damage
 		targetBI.CurrHP -= damage
-		if targetBI.CurrHP < 0 {
+		if targetBI.CurrHP <= 0 {
 			targetBI.CurrHP = 0
 			mr.TargetFainted = true // The move made the opponent faint
 		}
@@ -283,18 +478,27 @@ func RunMove(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move Mo
 		mr.Effectiveness = effectiveness
 		mr.CriticalHit = crit
 
+		// Let the target's held item react to the damage
+		mr.TargetHeldItem = runDamagedHeldItem(target, targetBI)
+
+		// Moves like Hyper Beam leave the user needing to recharge
+		if rechargeMoves[move.Name] {
+			userBI.RechargeMove = move.ID
+		}
+
 		// Check if the move has HP drain or knockback
-		if move.Drain > 0 {
+		if move.Drain != 0 {
 			// The move heals or hurts the user by a percent of the damage done
-			userBI.CurrHP += damage * move.Drain
-			maxHP := CalcIBHP(*user, *userBI) * move.Healing
+			drain := (damage * move.Drain) / 100
+			userBI.CurrHP += drain
+			maxHP := CalcIBHP(*user, *userBI)
 			if userBI.CurrHP > maxHP {
 				userBI.CurrHP = maxHP
-			} else if userBI.CurrHP < 0 {
+			} else if userBI.CurrHP <= 0 {
 				userBI.CurrHP = 0
 				mr.UserFainted = true // Knockback made the user faint
 			}
-			mr.TargetDrain = damage * move.Drain
+			mr.TargetDrain = drain
 		}
 	}
 
@@ -305,9 +509,12 @@ func RunMove(user, target *Pokemon, userBI, targetBI *PokemonBattleInfo, move Mo
 		// Check if the ailment "hit"
 		if rand.Intn(100)+1 <= move.EffectChance {
 			// Inflict the ailment so long as the target isn't already
-			// suffering from an ailment
-			if targetBI.Ailment == NoAilment {
-				targetBI.Ailment = move.Ailment
+			// suffering from an ailment and its ability allows it
+			inflicted, ar := inflictAilment(target, targetBI, move.Ailment)
+			if ar.Activated {
+				mr.TargetAbility = ar
+			}
+			if inflicted {
 				switch targetBI.Ailment {
 				case PoisonAilment:
 					mr.Poisoned = true
@@ -0,0 +1,89 @@
+package pkmn
+
+import "math/rand"
+
+// chargeMoves maps the PokeAPI names of moves that take a turn to charge up
+// before they hit to true if the user is semi-invulnerable while charging.
+var chargeMoves = map[string]bool{
+	"solar-beam":    false,
+	"skull-bash":    false,
+	"razor-wind":    false,
+	"sky-attack":    false,
+	"fly":           true,
+	"dig":           true,
+	"dive":          true,
+	"bounce":        true,
+	"phantom-force": true}
+
+// rechargeMoves contains the PokeAPI names of moves that the user has to
+// spend its next turn recharging after.
+var rechargeMoves = map[string]bool{
+	"hyper-beam":   true,
+	"giga-impact":  true,
+	"blast-burn":   true,
+	"hydro-cannon": true,
+	"frenzy-plant": true}
+
+// rampageMoves contains the PokeAPI names of moves that lock the user into
+// using them for two or three turns, after which the user becomes confused.
+var rampageMoves = map[string]bool{
+	"outrage":     true,
+	"thrash":      true,
+	"petal-dance": true}
+
+// protectMoves contains the PokeAPI names of moves that protect the user from
+// moves for the rest of the turn.
+var protectMoves = map[string]bool{
+	"protect": true,
+	"detect":  true}
+
+// ForcedMove returns the ID of the move the Pokemon has to use on its next
+// turn because it is charging, recharging or rampaging, or false if the
+// trainer is free to choose.
+func (pBI *PokemonBattleInfo) ForcedMove() (int, bool) {
+	switch {
+	case pBI.CurrHP <= 0:
+		return 0, false
+	case pBI.ChargingMove != 0:
+		return pBI.ChargingMove, true
+	case pBI.RechargeMove != 0:
+		return pBI.RechargeMove, true
+	case pBI.RampageMove != 0:
+		return pBI.RampageMove, true
+	default:
+		return 0, false
+	}
+}
+
+// SwitchOut clears all the move state that a Pokemon loses when it is
+// withdrawn from battle.
+func (pBI *PokemonBattleInfo) SwitchOut() {
+	pBI.ChoiceLockedMove = 0
+	pBI.ChargingMove = 0
+	pBI.SemiInvulnerable = false
+	pBI.RechargeMove = 0
+	pBI.RampageMove = 0
+	pBI.RampageTurns = 0
+	pBI.ProtectCount = 0
+	pBI.Protected = false
+}
+
+// EndTurn clears the move state that only lasts until the end of the turn.
+func (pBI *PokemonBattleInfo) EndTurn() {
+	pBI.Protected = false
+}
+
+// protectSucceeds decides if a protect move works, given how many times in a
+// row the user has already protected itself. Each success cuts the odds of
+// the next one to a third.
+func protectSucceeds(protectCount int) bool {
+	odds := 1
+	for i := 0; i < protectCount; i++ {
+		odds *= 3
+		if odds > 729 {
+			// The odds are practically zero at this point
+			return false
+		}
+	}
+	return rand.Intn(odds) == 0
+}
@@ -0,0 +1,112 @@
+package pkmn
+
+import "math/rand"
+
+// Nature represents a Pokemon's nature. Most natures raise one stat by 10%
+// and lower another by 10%, while the rest have no effect on stats.
+type Nature int
+
+const (
+	_ Nature = iota
+	HardyNature
+	LonelyNature
+	BraveNature
+	AdamantNature
+	NaughtyNature
+	BoldNature
+	DocileNature
+	RelaxedNature
+	ImpishNature
+	LaxNature
+	TimidNature
+	HastyNature
+	SeriousNature
+	JollyNature
+	NaiveNature
+	ModestNature
+	MildNature
+	QuietNature
+	BashfulNature
+	RashNature
+	CalmNature
+	GentleNature
+	SassyNature
+	CarefulNature
+	QuirkyNature
+)
+
+// NatureCount is the number of natures a Pokemon can have.
+const NatureCount = 25
+
+// natureStatOrder is the order of stats that natures are laid out in. Natures
+// are arranged in a 5x5 grid where the row is the raised stat and the column
+// is the lowered stat, so a nature that raises and lowers the same stat is
+// neutral.
+var natureStatOrder = [5]StatType{
+	AttackStatType,
+	DefenseStatType,
+	SpeedStatType,
+	SpecialAttackStatType,
+	SpecialDefenseStatType}
+
+var natureNames = [NatureCount]string{
+	"hardy", "lonely", "brave", "adamant", "naughty",
+	"bold", "docile", "relaxed", "impish", "lax",
+	"timid", "hasty", "serious", "jolly", "naive",
+	"modest", "mild", "quiet", "bashful", "rash",
+	"calm", "gentle", "sassy", "careful", "quirky"}
+
+// valid returns true if the nature is one of the known natures.
+func (n Nature) valid() bool {
+	return n >= HardyNature && n <= QuirkyNature
+}
+
+// Name returns the lowercase name of the nature, or an empty string if the
+// nature is not valid.
+func (n Nature) Name() string {
+	if !n.valid() {
+		return ""
+	}
+	return natureNames[n-HardyNature]
+}
+
+// Raises returns the stat the nature raises, and false if the nature does not
+// raise any stat.
+func (n Nature) Raises() (StatType, bool) {
+	if !n.valid() {
+		return 0, false
+	}
+	raised := natureStatOrder[int(n-HardyNature)/5]
+	lowered := natureStatOrder[int(n-HardyNature)%5]
+	return raised, raised != lowered
+}
+
+// Lowers returns the stat the nature lowers, and false if the nature does not
+// lower any stat.
+func (n Nature) Lowers() (StatType, bool) {
+	if !n.valid() {
+		return 0, false
+	}
+	raised := natureStatOrder[int(n-HardyNature)/5]
+	lowered := natureStatOrder[int(n-HardyNature)%5]
+	return lowered, raised != lowered
+}
+
+// StatPercent returns the percent that the given stat is multiplied by as a
+// result of the nature. It will be 110 for the raised stat, 90 for the
+// lowered stat, and 100 for everything else. Pokemon without a valid nature
+// are treated as having a neutral one.
+func (n Nature) StatPercent(t StatType) int {
+	if raised, ok := n.Raises(); ok && raised == t {
+		return 110
+	}
+	if lowered, ok := n.Lowers(); ok && lowered == t {
+		return 90
+	}
+	return 100
+}
+
+// RandomNature returns a randomly chosen nature.
+func RandomNature() Nature {
+	return HardyNature + Nature(rand.Intn(NatureCount))
+}
@@ -0,0 +1,116 @@
+package pkmn
+
+import "sort"
+
+// NationalDexSize is the number of species in the National Pokedex, up to
+// the last region the game knows about.
+const NationalDexSize = 721
+
+// regionSpecies is the range of National Pokedex IDs each region introduced.
+var regionSpecies = map[Region][2]int{
+	KantoRegion:  {1, 151},
+	JohtoRegion:  {152, 251},
+	HoennRegion:  {252, 386},
+	SinnohRegion: {387, 493},
+	UnovaRegion:  {494, 649},
+	KalosRegion:  {650, 721}}
+
+// Species returns the first and last National Pokedex IDs of the species
+// introduced in the region.
+func (r Region) Species() (int, int) {
+	ids := regionSpecies[r]
+	return ids[0], ids[1]
+}
+
+// SpeciesCount returns the number of species introduced in the region.
+func (r Region) SpeciesCount() int {
+	first, last := r.Species()
+	return last - first + 1
+}
+
+// Pokedex records which species a trainer has seen and caught. Species are
+// kept as sorted lists of National Pokedex IDs.
+type Pokedex struct {
+	Seen   []int
+	Caught []int
+}
+
+// insertSpecies adds the species to the sorted list if it isn't already
+// there.
+func insertSpecies(ids []int, id int) []int {
+	i := sort.SearchInts(ids, id)
+	if i < len(ids) && ids[i] == id {
+		return ids
+	}
+	ids = append(ids, 0)
+	copy(ids[i+1:], ids[i:])
+	ids[i] = id
+	return ids
+}
+
+// hasSpecies returns true if the sorted list contains the species.
+func hasSpecies(ids []int, id int) bool {
+	i := sort.SearchInts(ids, id)
+	return i < len(ids) && ids[i] == id
+}
+
+// countSpecies returns how many species in the sorted list are between first
+// and last, inclusive.
+func countSpecies(ids []int, first, last int) int {
+	return sort.SearchInts(ids, last+1) - sort.SearchInts(ids, first)
+}
+
+// See records that the trainer has seen the species.
+func (d *Pokedex) See(id int) {
+	d.Seen = insertSpecies(d.Seen, id)
+}
+
+// Catch records that the trainer has caught the species. Caught species are
+// always seen as well.
+func (d *Pokedex) Catch(id int) {
+	d.See(id)
+	d.Caught = insertSpecies(d.Caught, id)
+}
+
+// HasSeen returns true if the trainer has seen the species.
+func (d *Pokedex) HasSeen(id int) bool {
+	return hasSpecies(d.Seen, id)
+}
+
+// HasCaught returns true if the trainer has caught the species.
+func (d *Pokedex) HasCaught(id int) bool {
+	return hasSpecies(d.Caught, id)
+}
+
+// SeenIn returns how many of the species introduced in the region the trainer
+// has seen.
+func (d *Pokedex) SeenIn(r Region) int {
+	first, last := r.Species()
+	return countSpecies(d.Seen, first, last)
+}
+
+// CaughtIn returns how many of the species introduced in the region the
+// trainer has caught.
+func (d *Pokedex) CaughtIn(r Region) int {
+	first, last := r.Species()
+	return countSpecies(d.Caught, first, last)
+}
+
+// DexEntry is what the Pokedex shows about a species.
+type DexEntry struct {
+	ID        int
+	Name      string
+	Type1     string
+	Type2     string
+	SpriteURL string
+
+	// Base stats
+	HP        int
+	Attack    int
+	Defense   int
+	SpAttack  int
+	SpDefense int
+	Speed     int
+
+	FlavorText string
+}
@@ -1,20 +1,50 @@
 package pkmn
 
-import "github.com/pkg/errors"
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// MaxNicknameLength is the most characters a Pokemon's nickname can have.
+const MaxNicknameLength = 12
 
 type Pokemon struct {
 	UUID string
 
 	SpriteURL string
+	// ShinySpriteURL is the sprite shown in place of SpriteURL if the Pokemon
+	// is shiny.
+	ShinySpriteURL string
+
+	ID   int
+	Name string
+	// Form is the name of the Pokemon's alternate form or regional variant,
+	// like "alola" or "sandy", or an empty string if it's in the default form
+	// of its species.
+	Form string
+	// Shiny is true if the Pokemon is a rare, differently colored shiny
+	// Pokemon.
+	Shiny bool
+	// Nickname is the name the trainer gave the Pokemon, or an empty string
+	// if it goes by its species name.
+	Nickname string
 
-	ID     int
-	Name   string
 	Height int
 	Weight int
 	Type1  string
 	Type2  string
 
-	Level int
+	Level    int
+	Nature   Nature
+	Ability  string
+	HeldItem Item
+	// AbilitySlot is the PokeAPI slot of the Pokemon's ability among its
+	// species' regular abilities. The Pokemon keeps the ability in this slot
+	// when it evolves. Pokemon made before slots were recorded have a slot of
+	// 0.
+	AbilitySlot int
 
 	HP        Stat
 	Attack    Stat
@@ -31,10 +61,46 @@ type Pokemon struct {
 	CatchRate  int
 	GrowthRate GrowthRate
 
+	// Slot is the Pokemon's position in its trainer's party, starting at 0.
 	Slot int
 
 	BaseExperience int
 	Experience     int
+	// ExperienceVersion is the version of the meaning of Experience the
+	// Pokemon was saved with. See MigrateExperience.
+	ExperienceVersion int
+
+	// Friendship is how much the Pokemon likes its trainer, from 0 to
+	// MaxFriendship.
+	Friendship int
+
+	// OriginalTrainer is the UUID of the trainer the Pokemon first belonged
+	// to, or an empty string if it has never been traded.
+	OriginalTrainer string
+	// OriginalTrainerName is the name of the trainer the Pokemon first
+	// belonged to, or an empty string if it has never been traded.
+	OriginalTrainerName string
+
+	// Damage is how much HP the Pokemon has lost since it was last healed.
+	// It carries over from one battle to the next.
+	Damage int
+	// Ailment is the status condition the Pokemon has outside of battle.
+	Ailment Ailment
+	// PPUsed is how many times the move in each move slot has been used
+	// since the Pokemon was last healed, indexed by move slot starting at 0.
+	PPUsed []int
+
+	// PendingMoves are the IDs of moves the Pokemon reached the level for but
+	// hasn't learned yet because its move slots are full.
+	PendingMoves []int
+
+	// PendingEvolution is the PokeAPI ID of the species the Pokemon is about
+	// to evolve into while the trainer decides whether to let it, or 0 if it
+	// isn't evolving.
+	PendingEvolution int
+	// CancelledEvolutionLevel is the level the Pokemon was at when the
+	// trainer last cancelled its evolution, or 0 if they haven't.
+	CancelledEvolutionLevel int
 }
 
 type GrowthRate int
@@ -49,6 +115,27 @@ const (
 	FluctuatingGrowthRate
 )
 
+// DisplayName returns the name the Pokemon goes by, which is its nickname if
+// it has one and its species name otherwise.
+func (pkmn *Pokemon) DisplayName() string {
+	if pkmn.Nickname != "" {
+		return pkmn.Nickname
+	}
+	return pkmn.Name
+}
+
+// SetNickname gives the Pokemon the given nickname. An empty nickname makes the
+// Pokemon go by its species name again. An error is returned if the nickname
+// is too long.
+func (pkmn *Pokemon) SetNickname(nickname string) error {
+	nickname = strings.TrimSpace(nickname)
+	if utf8.RuneCountInString(nickname) > MaxNicknameLength {
+		return errors.Errorf("nickname '%v' is longer than %v characters", nickname, MaxNicknameLength)
+	}
+	pkmn.Nickname = nickname
+	return nil
+}
+
 // MoveCount returns the number of moves the Pokemon has.
 func (pkmn *Pokemon) MoveCount() int {
 	cnt := 0
@@ -108,6 +195,7 @@ func (pkmn *Pokemon) ReplaceMove(oldMoveSlot, newMoveID int) error {
 	default:
 		panic("invalid move slot")
 	}
+	pkmn.restorePP(oldMoveSlot)
 
 	return nil
 }
@@ -115,15 +203,26 @@ func (pkmn *Pokemon) ReplaceMove(oldMoveSlot, newMoveID int) error {
 func (pkmn *Pokemon) LearnMove(moveID int) error {
 	if pkmn.Move1 == 0 {
 		pkmn.Move1 = moveID
+		pkmn.restorePP(1)
 	} else if pkmn.Move2 == 0 {
 		pkmn.Move2 = moveID
+		pkmn.restorePP(2)
 	} else if pkmn.Move3 == 0 {
 		pkmn.Move3 = moveID
+		pkmn.restorePP(3)
 	} else if pkmn.Move4 == 0 {
 		pkmn.Move4 = moveID
+		pkmn.restorePP(4)
 	} else {
 		return errors.New("attempt to give a Pokemon a new move when all move slots are full")
 	}
 
 	return nil
 }
+
+// KnowsMove returns true if the Pokemon has the move with the given ID in one
+// of its move slots.
+func (pkmn *Pokemon) KnowsMove(moveID int) bool {
+	return moveID != 0 && (pkmn.Move1 == moveID || pkmn.Move2 == moveID ||
+		pkmn.Move3 == moveID || pkmn.Move4 == moveID)
+}
@@ -1,5 +1,7 @@
 package pkmn
 
+// Region is one of the regions of the Pokemon world. Trainers earn badges and
+// find wild Pokemon separately in each region.
 type Region int
 
 const (
@@ -12,3 +14,70 @@ const (
 )
 
 const RegionCount int = 6
+
+var regionNames = map[Region]string{
+	KantoRegion:  "kanto",
+	JohtoRegion:  "johto",
+	HoennRegion:  "hoenn",
+	SinnohRegion: "sinnoh",
+	UnovaRegion:  "unova",
+	KalosRegion:  "kalos"}
+
+// Name returns the lowercase name of the region.
+func (r Region) Name() string {
+	return regionNames[r]
+}
+
+// NameToRegion returns the region with the given lowercase name, or false if
+// no such region exists.
+func NameToRegion(name string) (Region, bool) {
+	for r, regionName := range regionNames {
+		if regionName == name {
+			return r, true
+		}
+	}
+	return KantoRegion, false
+}
+
+// badgeCounter returns a pointer to the trainer's badge count for the region.
+func (t *Trainer) badgeCounter(r Region) *int {
+	switch r {
+	case KantoRegion:
+		return &t.KantoBadges
+	case JohtoRegion:
+		return &t.JohtoBadges
+	case HoennRegion:
+		return &t.HoennBadges
+	case SinnohRegion:
+		return &t.SinnohBadges
+	case UnovaRegion:
+		return &t.UnovaBadges
+	case KalosRegion:
+		return &t.KalosBadges
+	default:
+		panic("unsupported region")
+	}
+}
+
+// Badges returns the number of badges the trainer has earned in the region.
+func (t *Trainer) Badges(r Region) int {
+	return *t.badgeCounter(r)
+}
+
+// TotalBadges returns the number of badges the trainer has earned across every
+// region.
+func (t *Trainer) TotalBadges() int {
+	total := 0
+	for r := Region(0); r < Region(RegionCount); r++ {
+		total += t.Badges(r)
+	}
+	return total
+}
+
+// AwardBadge gives the trainer another badge in the region. The areas the new
+// badge unlocks are returned.
+func (t *Trainer) AwardBadge(r Region) []Area {
+	before := unlockedAreaCount(r, t.Badges(r))
+	*t.badgeCounter(r)++
+	return regionAreas[r][before:unlockedAreaCount(r, t.Badges(r))]
+}
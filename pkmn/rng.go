@@ -0,0 +1,31 @@
+package pkmn
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RNG is a source of random numbers. Anything that depends on chance takes an
+// RNG so that a predictable one can be used in its place.
+type RNG interface {
+	// Intn returns a random number in [0,n).
+	Intn(n int) int
+}
+
+// systemRand is the random number generator shared by every SystemRNG.
+var systemRand = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// SystemRNG is an RNG seeded with the time the program started. It's safe to
+// use from multiple goroutines.
+type SystemRNG struct{}
+
+// Intn returns a random number in [0,n).
+func (r SystemRNG) Intn(n int) int {
+	systemRand.Lock()
+	defer systemRand.Unlock()
+	return systemRand.Rand.Intn(n)
+}
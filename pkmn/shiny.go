@@ -0,0 +1,35 @@
+package pkmn
+
+import "github.com/pkg/errors"
+
+// DefaultShinyOdds is the default chance of a wild Pokemon being shiny, as in
+// one in this many.
+const DefaultShinyOdds = 4096
+
+// shinyOdds is the current chance of a wild Pokemon being shiny, as in one in
+// this many.
+var shinyOdds = DefaultShinyOdds
+
+// SetShinyOdds changes the chance of a wild Pokemon being shiny to one in the
+// given number. An error is returned if the odds are less than one.
+func SetShinyOdds(odds int) error {
+	if odds < 1 {
+		return errors.Errorf("invalid shiny odds 1 in %v", odds)
+	}
+	shinyOdds = odds
+	return nil
+}
+
+// RollShiny returns true if a newly found wild Pokemon should be shiny.
+func RollShiny(rng RNG) bool {
+	return rng.Intn(shinyOdds) == 0
+}
+
+// Sprite returns the URL of the Pokemon's sprite, which is its shiny sprite if
+// it's shiny and one is available.
+func (pkmn *Pokemon) Sprite() string {
+	if pkmn.Shiny && pkmn.ShinySpriteURL != "" {
+		return pkmn.ShinySpriteURL
+	}
+	return pkmn.SpriteURL
+}
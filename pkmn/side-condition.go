@@ -0,0 +1,246 @@
+package pkmn
+
+import "github.com/pkg/errors"
+
+// SideCondition is an effect that applies to one trainer's side of the field,
+// affecting every Pokemon that trainer sends out.
+type SideCondition int
+
+const (
+	NoSideCondition SideCondition = iota
+	StealthRockSideCondition
+	SpikesSideCondition
+	ToxicSpikesSideCondition
+	ReflectSideCondition
+	LightScreenSideCondition
+	TailwindSideCondition
+)
+
+const (
+	// MaxSpikes is the most layers of Spikes that can be laid on one side.
+	MaxSpikes = 3
+	// MaxToxicSpikes is the most layers of Toxic Spikes that can be laid on
+	// one side.
+	MaxToxicSpikes = 2
+	// ScreenDuration is the number of turns Reflect and Light Screen last.
+	ScreenDuration = 5
+	// TailwindDuration is the number of turns Tailwind lasts.
+	TailwindDuration = 4
+)
+
+// sideConditionMoves maps the PokeAPI names of moves that create side
+// conditions to the condition they create.
+var sideConditionMoves = map[string]SideCondition{
+	"stealth-rock": StealthRockSideCondition,
+	"spikes":       SpikesSideCondition,
+	"toxic-spikes": ToxicSpikesSideCondition,
+	"reflect":      ReflectSideCondition,
+	"light-screen": LightScreenSideCondition,
+	"tailwind":     TailwindSideCondition}
+
+// Name returns the lowercase name of the side condition.
+func (sc SideCondition) Name() string {
+	switch sc {
+	case StealthRockSideCondition:
+		return "stealth rock"
+	case SpikesSideCondition:
+		return "spikes"
+	case ToxicSpikesSideCondition:
+		return "toxic spikes"
+	case ReflectSideCondition:
+		return "reflect"
+	case LightScreenSideCondition:
+		return "light screen"
+	case TailwindSideCondition:
+		return "tailwind"
+	default:
+		return ""
+	}
+}
+
+// IsHazard returns true if the side condition is an entry hazard. Entry
+// hazards are laid on the opponent's side of the field, while other conditions
+// protect the user's side.
+func (sc SideCondition) IsHazard() bool {
+	return sc == StealthRockSideCondition || sc == SpikesSideCondition || sc == ToxicSpikesSideCondition
+}
+
+// AddSideCondition puts the side condition on the trainer's side of the
+// field. It returns false if the condition is already in effect or can't be
+// laid any more times, in which case nothing changes.
+func (tbi *TrainerBattleInfo) AddSideCondition(sc SideCondition) bool {
+	switch sc {
+	case StealthRockSideCondition:
+		if tbi.StealthRock {
+			return false
+		}
+		tbi.StealthRock = true
+	case SpikesSideCondition:
+		if tbi.Spikes >= MaxSpikes {
+			return false
+		}
+		tbi.Spikes++
+	case ToxicSpikesSideCondition:
+		if tbi.ToxicSpikes >= MaxToxicSpikes {
+			return false
+		}
+		tbi.ToxicSpikes++
+	case ReflectSideCondition:
+		if tbi.ReflectTurns > 0 {
+			return false
+		}
+		tbi.ReflectTurns = ScreenDuration
+	case LightScreenSideCondition:
+		if tbi.LightScreenTurns > 0 {
+			return false
+		}
+		tbi.LightScreenTurns = ScreenDuration
+	case TailwindSideCondition:
+		if tbi.TailwindTurns > 0 {
+			return false
+		}
+		tbi.TailwindTurns = TailwindDuration
+	default:
+		return false
+	}
+
+	return true
+}
+
+// AdvanceSideConditions counts down the turns left in the timed conditions on
+// the trainer's side of the field and returns the conditions that ran out.
+// Entry hazards stay until the battle ends.
+func (tbi *TrainerBattleInfo) AdvanceSideConditions() []SideCondition {
+	var ended []SideCondition
+
+	countDown := func(turns *int, sc SideCondition) {
+		if *turns <= 0 {
+			return
+		}
+		*turns--
+		if *turns == 0 {
+			ended = append(ended, sc)
+		}
+	}
+	countDown(&tbi.ReflectTurns, ReflectSideCondition)
+	countDown(&tbi.LightScreenTurns, LightScreenSideCondition)
+	countDown(&tbi.TailwindTurns, TailwindSideCondition)
+
+	return ended
+}
+
+// screenDamageModifier returns the multiplier that the screens on the target's
+// side of the field apply to the damage of the move. Reflect halves the damage
+// of physical moves and Light Screen halves the damage of special moves, but
+// critical hits go right through them.
+func screenDamageModifier(targetSide *TrainerBattleInfo, move Move, crit bool) float64 {
+	if crit {
+		return 1.0
+	}
+	if move.DamageClass == PhysicalDamageClass && targetSide.ReflectTurns > 0 {
+		return 0.5
+	}
+	if move.DamageClass == SpecialDamageClass && targetSide.LightScreenTurns > 0 {
+		return 0.5
+	}
+	return 1.0
+}
+
+// tailwindSpeedModifier returns the multiplier that Tailwind applies to the
+// speed of Pokemon on the given side of the field.
+func tailwindSpeedModifier(side TrainerBattleInfo) float64 {
+	if side.TailwindTurns > 0 {
+		return 2.0
+	}
+	return 1.0
+}
+
+// HazardReport describes what happened when a Pokemon entered a side of the
+// field with entry hazards on it.
+type HazardReport struct {
+	StealthRockDamage int
+	SpikesDamage      int
+	// Poisoned is true if Toxic Spikes poisoned the Pokemon.
+	Poisoned bool
+	// ToxicSpikesAbsorbed is true if the Pokemon was a poison type and
+	// cleared the Toxic Spikes away by entering.
+	ToxicSpikesAbsorbed bool
+	Fainted             bool
+}
+
+// Activated returns true if any hazard had an effect on the Pokemon.
+func (hr HazardReport) Activated() bool {
+	return hr.StealthRockDamage > 0 || hr.SpikesDamage > 0 || hr.Poisoned || hr.ToxicSpikesAbsorbed
+}
+
+// isGrounded returns true if the Pokemon is touching the ground, which makes
+// it vulnerable to Spikes and Toxic Spikes.
+func isGrounded(p *Pokemon) bool {
+	return !hasType(p, "flying") && p.Ability != "levitate"
+}
+
+// RunEntryHazards applies the effects of the entry hazards on the given side
+// of the field to the Pokemon entering it.
+func RunEntryHazards(p *Pokemon, pBI *PokemonBattleInfo, side *TrainerBattleInfo) (HazardReport, error) {
+	var hr HazardReport
+
+	if pBI.CurrHP <= 0 {
+		return hr, nil
+	}
+	maxHP := CalcIBHP(*p, *pBI)
+
+	// Stealth Rock hurts based on how effective rock moves are against the
+	// Pokemon
+	if side.StealthRock {
+		typeEff := 1.0
+		for _, typeName := range []string{p.Type1, p.Type2} {
+			if typeName == "" {
+				continue
+			}
+			t, ok := NameToType(typeName)
+			if !ok {
+				return HazardReport{}, errors.New("no type found for type name '" + typeName + "'")
+			}
+			typeEff *= t.Mod("rock")
+		}
+		hr.StealthRockDamage = int(float64(maxHP) * typeEff / 8.0)
+		if hr.StealthRockDamage < 1 {
+			hr.StealthRockDamage = 1
+		}
+	}
+
+	if isGrounded(p) {
+		// Spikes hurt more the more layers there are
+		switch side.Spikes {
+		case 1:
+			hr.SpikesDamage = maxHP / 8
+		case 2:
+			hr.SpikesDamage = maxHP / 6
+		case 3:
+			hr.SpikesDamage = maxHP / 4
+		}
+		if side.Spikes > 0 && hr.SpikesDamage < 1 {
+			hr.SpikesDamage = 1
+		}
+
+		// Poison types clear Toxic Spikes away, and Pokemon that can't be
+		// poisoned are unaffected by them
+		if side.ToxicSpikes > 0 {
+			if hasType(p, "poison") {
+				side.ToxicSpikes = 0
+				hr.ToxicSpikesAbsorbed = true
+			} else if !hasType(p, "steel") {
+				hr.Poisoned, _ = inflictAilment(p, pBI, PoisonAilment)
+			}
+		}
+	}
+
+	// Deal the damage
+	pBI.CurrHP -= hr.StealthRockDamage + hr.SpikesDamage
+	if pBI.CurrHP <= 0 {
+		pBI.CurrHP = 0
+		hr.Fainted = true
+	}
+
+	return hr, nil
+}
@@ -1,84 +1,274 @@
 package pkmn
 
-// Stat is the information on a single stat.
+import "math/rand"
+
+// Stat is the information on a single stat. EVYield is the amount of effort
+// values a Pokemon gives for this stat when it is defeated.
 type Stat struct {
-	Base int
-	IV   int
-	EV   int
+	Base    int
+	IV      int
+	EV      int
+	EVYield int
+}
+
+// MaxIV is the highest value an individual value can have.
+const MaxIV = 31
+
+const (
+	// MaxStatEV is the highest amount of effort values a single stat can
+	// have.
+	MaxStatEV = 252
+	// MaxTotalEV is the highest amount of effort values a Pokemon can have
+	// across all of its stats.
+	MaxTotalEV = 510
+)
+
+// RandomIV returns a randomly generated individual value.
+func RandomIV() int {
+	return rand.Intn(MaxIV + 1)
+}
+
+const (
+	// MinStatStage is the lowest a stat stage can go in battle.
+	MinStatStage = -6
+	// MaxStatStage is the highest a stat stage can go in battle.
+	MaxStatStage = 6
+)
+
+// ClampStatStage returns the given stat stage limited to the range of
+// possible stat stages.
+func ClampStatStage(stage int) int {
+	if stage < MinStatStage {
+		return MinStatStage
+	} else if stage > MaxStatStage {
+		return MaxStatStage
+	}
+	return stage
+}
+
+// applyStatStage applies the multiplier of the given stat stage to the stat
+// value. Like in the main series games, positive stages multiply the stat by
+// (2+stage)/2 and negative stages by 2/(2-stage), rounding down.
+func applyStatStage(val, stage int) int {
+	stage = ClampStatStage(stage)
+	if stage >= 0 {
+		return (val * (2 + stage)) / 2
+	}
+	return (val * 2) / (2 - stage)
+}
+
+// CalcHP calculates the HP value of a Pokemon at the given level from its HP
+// stat information.
+func CalcHP(s Stat, level int) int {
+	// Pokemon with a base HP of 1 (Shedinja) always have exactly one HP
+	if s.Base == 1 {
+		return 1
+	}
+	return ((((2*s.Base + s.IV + (s.EV / 4)) * level) / 100) + level + 10)
+}
+
+// CalcStat calculates the value of any stat besides HP at the given level from
+// its stat information, taking the effect of the given nature into account.
+// The stat type decides how the nature affects the stat.
+func CalcStat(s Stat, level int, nature Nature, t StatType) int {
+	val := ((((2*s.Base + s.IV + (s.EV / 4)) * level) / 100) + 5)
+	return (val * nature.StatPercent(t)) / 100
+}
+
+// StatOfType returns the Pokemon's stat information for the given stat type.
+// Accuracy and evasion are not backed by stat information, so an empty stat
+// is returned for those.
+func (pkmn *Pokemon) StatOfType(t StatType) Stat {
+	switch t {
+	case HPStatType:
+		return pkmn.HP
+	case AttackStatType:
+		return pkmn.Attack
+	case DefenseStatType:
+		return pkmn.Defense
+	case SpecialAttackStatType:
+		return pkmn.SpAttack
+	case SpecialDefenseStatType:
+		return pkmn.SpDefense
+	case SpeedStatType:
+		return pkmn.Speed
+	default:
+		return Stat{}
+	}
+}
+
+// IVTotal returns the sum of all the Pokemon's individual values.
+func (pkmn *Pokemon) IVTotal() int {
+	return pkmn.HP.IV + pkmn.Attack.IV + pkmn.Defense.IV + pkmn.SpAttack.IV +
+		pkmn.SpDefense.IV + pkmn.Speed.IV
+}
+
+// EVTotal returns the sum of all the Pokemon's effort values.
+func (pkmn *Pokemon) EVTotal() int {
+	return pkmn.HP.EV + pkmn.Attack.EV + pkmn.Defense.EV + pkmn.SpAttack.EV +
+		pkmn.SpDefense.EV + pkmn.Speed.EV
 }
 
-func statMod(mod int) float64 {
-	if mod >= 0 {
-		return float64(mod+2) / 2.0
-	} else {
-		return 2.0 / float64((-mod)+2)
+// GainEVs awards the Pokemon the effort value yield of the given defeated
+// Pokemon. No stat will go above MaxStatEV and the total will not go above
+// MaxTotalEV. It returns the total amount of effort values gained.
+//
+// Stats are always calculated from the current effort values, so the new
+// effort values are reflected in the Pokemon's stats from here on out,
+// including after it levels up.
+func (pkmn *Pokemon) GainEVs(defeated Pokemon) int {
+	gained := 0
+
+	stats := []struct {
+		stat  *Stat
+		yield int
+	}{
+		{&pkmn.HP, defeated.HP.EVYield},
+		{&pkmn.Attack, defeated.Attack.EVYield},
+		{&pkmn.Defense, defeated.Defense.EVYield},
+		{&pkmn.SpAttack, defeated.SpAttack.EVYield},
+		{&pkmn.SpDefense, defeated.SpDefense.EVYield},
+		{&pkmn.Speed, defeated.Speed.EVYield}}
+
+	for _, s := range stats {
+		gain := s.yield
+		// Respect the per-stat cap
+		if s.stat.EV+gain > MaxStatEV {
+			gain = MaxStatEV - s.stat.EV
+		}
+		// Respect the total cap
+		if pkmn.EVTotal()+gain > MaxTotalEV {
+			gain = MaxTotalEV - pkmn.EVTotal()
+		}
+		if gain <= 0 {
+			continue
+		}
+
+		s.stat.EV += gain
+		gained += gain
 	}
+
+	return gained
 }
 
-// CalcOOBStat calculates the actual stat value of any stat besides HP, not
-// including in-battle effects.
-func CalcOOBStat(s Stat, pkmn Pokemon) int {
-	return ((((2*s.Base + s.IV + (s.EV / 4)) * pkmn.Level) / 100) + 5)
+// StatStage returns the current stat stage of the given stat type.
+func (pkmnBI *PokemonBattleInfo) StatStage(t StatType) int {
+	switch t {
+	case AttackStatType:
+		return pkmnBI.AttStage
+	case DefenseStatType:
+		return pkmnBI.DefStage
+	case SpecialAttackStatType:
+		return pkmnBI.SpAttStage
+	case SpecialDefenseStatType:
+		return pkmnBI.SpDefStage
+	case SpeedStatType:
+		return pkmnBI.SpeedStage
+	case AccuracyStatType:
+		return pkmnBI.AccuracyStage
+	case EvasionStatType:
+		return pkmnBI.EvasionStage
+	default:
+		return 0
+	}
+}
+
+// ChangeStatStage changes the stat stage of the given stat type by the given
+// amount, keeping the result within the possible range of stat stages. It
+// returns the amount the stage actually changed by, which may be zero if the
+// stage is already at its limit.
+func (pkmnBI *PokemonBattleInfo) ChangeStatStage(t StatType, change int) int {
+	var stage *int
+	switch t {
+	case AttackStatType:
+		stage = &pkmnBI.AttStage
+	case DefenseStatType:
+		stage = &pkmnBI.DefStage
+	case SpecialAttackStatType:
+		stage = &pkmnBI.SpAttStage
+	case SpecialDefenseStatType:
+		stage = &pkmnBI.SpDefStage
+	case SpeedStatType:
+		stage = &pkmnBI.SpeedStage
+	case AccuracyStatType:
+		stage = &pkmnBI.AccuracyStage
+	case EvasionStatType:
+		stage = &pkmnBI.EvasionStage
+	default:
+		return 0
+	}
+
+	prev := *stage
+	*stage = ClampStatStage(*stage + change)
+	return *stage - prev
+}
+
+// CalcOOBStat calculates the actual value of the given stat type besides HP,
+// not including in-battle effects.
+func CalcOOBStat(pkmn Pokemon, t StatType) int {
+	return CalcStat(pkmn.StatOfType(t), pkmn.Level, pkmn.Nature, t)
 }
 
 // CalcOOBHP calculates the actual HP value of a Pokemon, not including in-battle
 // effects.
 func CalcOOBHP(s Stat, pkmn Pokemon) int {
-	return ((((2*s.Base + s.IV + (s.EV / 4)) * pkmn.Level) / 100) + pkmn.Level + 10)
+	return CalcHP(s, pkmn.Level)
+}
+
+// CalcIBStat calculates the in-battle value of the given stat type besides
+// HP, accuracy and evasion, applying the current stat stage.
+func CalcIBStat(pkmn Pokemon, pkmnBI PokemonBattleInfo, t StatType) int {
+	return applyStatStage(CalcOOBStat(pkmn, t), pkmnBI.StatStage(t))
 }
 
-// CalcIBHP calculates the in-battle attack stat of the Pokemon.
+// CalcIBHP calculates the in-battle max HP of the Pokemon.
 func CalcIBHP(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	return CalcOOBStat(pkmn.HP, pkmn)
+	return CalcOOBHP(pkmn.HP, pkmn)
 }
 
 // CalcIBAttack calculates the in-battle attack stat of the Pokemon.
 func CalcIBAttack(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	return int(float64(((((2*pkmn.Attack.Base + pkmn.Attack.IV + (pkmn.Attack.EV / 4)) * pkmn.Level) / 100) + 5)) * statMod(pkmnBI.AttStage))
+	return CalcIBStat(pkmn, pkmnBI, AttackStatType)
 }
 
 // CalcIBDefense calculates the in-battle defense stat of the Pokemon.
 func CalcIBDefense(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	return int(float64(((((2*pkmn.Defense.Base + pkmn.Defense.IV + (pkmn.Defense.EV / 4)) * pkmn.Level) / 100) + 5)) * statMod(pkmnBI.DefStage))
+	return CalcIBStat(pkmn, pkmnBI, DefenseStatType)
 }
 
 // CalcIBSpAtt calculates the in-battle special attack stat of the Pokemon.
 func CalcIBSpAtt(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	return int(float64(((((2*pkmn.SpAttack.Base + pkmn.SpAttack.IV + (pkmn.SpAttack.EV / 4)) * pkmn.Level) / 100) + 5)) * statMod(pkmnBI.SpAttStage))
+	return CalcIBStat(pkmn, pkmnBI, SpecialAttackStatType)
 }
 
 // CalcIBSpDef calculates the in-battle special defense stat of the Pokemon.
 func CalcIBSpDef(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	return int(float64(((((2*pkmn.SpDefense.Base + pkmn.SpDefense.IV + (pkmn.SpDefense.EV / 4)) * pkmn.Level) / 100) + 5)) * statMod(pkmnBI.SpDefStage))
+	return CalcIBStat(pkmn, pkmnBI, SpecialDefenseStatType)
 }
 
 // CalcIBSpeed calculates the in-battle speed stat of the Pokemon.
 func CalcIBSpeed(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	return int(float64(((((2*pkmn.Speed.Base + pkmn.Speed.IV + (pkmn.Speed.EV / 4)) * pkmn.Level) / 100) + 5)) * statMod(pkmnBI.SpeedStage))
+	return CalcIBStat(pkmn, pkmnBI, SpeedStatType)
 }
 
 // CalcIBAccuracy calculates the in-battle accuracy of the Pokemon in percent.
 // This value alone isn't very meaningful, but it works when plugged into the
 // move accuracy function.
 func CalcIBAccuracy(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	if pkmnBI.AccuracyStage > 0 {
-		return int(100.0 * ((3.0 + float64(pkmnBI.AccuracyStage)) / 3.0))
-	} else if pkmnBI.AccuracyStage < 0 {
-		return int(100.0 * (3.0 / (3.0 + float64(pkmnBI.AccuracyStage))))
-	} else {
-		return 100
+	stage := ClampStatStage(pkmnBI.AccuracyStage)
+	if stage >= 0 {
+		return (100 * (3 + stage)) / 3
 	}
+	return (100 * 3) / (3 - stage)
 }
 
 // CalcIBEvasion calculates the in-battle evasion of the Pokemon in percent.
 // This value alone isn't very meaningful, but it works when plugged into the
-// move accuracy function.
+// move accuracy function, where the user's accuracy is divided by it.
 func CalcIBEvasion(pkmn Pokemon, pkmnBI PokemonBattleInfo) int {
-	if pkmnBI.EvasionStage > 0 {
-		return int(100.0 * (3.0 / (3.0 + float64(pkmnBI.EvasionStage))))
-	} else if pkmnBI.EvasionStage < 0 {
-		return int(100.0 * ((3.0 + float64(pkmnBI.EvasionStage)) / 3.0))
-	} else {
-		return 100
+	stage := ClampStatStage(pkmnBI.EvasionStage)
+	if stage >= 0 {
+		return (100 * (3 + stage)) / 3
 	}
+	return (100 * 3) / (3 - stage)
 }
@@ -0,0 +1,191 @@
+package pkmn
+
+import "testing"
+
+func TestCalcHP(t *testing.T) {
+	tests := []struct {
+		name  string
+		stat  Stat
+		level int
+		want  int
+	}{
+		// Bulbapedia's example Garchomp
+		{"garchomp", Stat{Base: 108, IV: 24, EV: 74}, 78, 289},
+		{"blissey max", Stat{Base: 255, IV: 31, EV: 252}, 100, 714},
+		{"blissey min", Stat{Base: 255}, 100, 620},
+		{"pikachu level 1", Stat{Base: 35}, 1, 11},
+		{"pikachu level 50", Stat{Base: 35, IV: 31}, 50, 110},
+		{"shedinja", Stat{Base: 1, IV: 31, EV: 252}, 100, 1},
+	}
+
+	for _, test := range tests {
+		if got := CalcHP(test.stat, test.level); got != test.want {
+			t.Errorf("%v: CalcHP(%+v, %v) = %v, want %v", test.name, test.stat, test.level, got, test.want)
+		}
+	}
+}
+
+func TestCalcStat(t *testing.T) {
+	tests := []struct {
+		name     string
+		stat     Stat
+		level    int
+		nature   Nature
+		statType StatType
+		want     int
+	}{
+		// Bulbapedia's example Garchomp, which is adamant
+		{"garchomp attack", Stat{Base: 130, IV: 12, EV: 190}, 78, AdamantNature, AttackStatType, 278},
+		{"garchomp defense", Stat{Base: 95, IV: 30, EV: 91}, 78, AdamantNature, DefenseStatType, 193},
+		{"garchomp special attack", Stat{Base: 80, IV: 16, EV: 48}, 78, AdamantNature, SpecialAttackStatType, 135},
+		{"garchomp special defense", Stat{Base: 85, IV: 23, EV: 84}, 78, AdamantNature, SpecialDefenseStatType, 171},
+		{"garchomp speed", Stat{Base: 102, IV: 5, EV: 23}, 78, AdamantNature, SpeedStatType, 171},
+		// The same speed with every kind of nature
+		{"neutral speed", Stat{Base: 90, IV: 31, EV: 252}, 50, HardyNature, SpeedStatType, 142},
+		{"raised speed", Stat{Base: 90, IV: 31, EV: 252}, 50, TimidNature, SpeedStatType, 156},
+		{"lowered speed", Stat{Base: 90, IV: 31, EV: 252}, 50, BraveNature, SpeedStatType, 127},
+		{"unrelated nature", Stat{Base: 90, IV: 31, EV: 252}, 50, ModestNature, SpeedStatType, 142},
+		// IVs and EVs on their own
+		{"no IVs or EVs", Stat{Base: 100}, 100, HardyNature, AttackStatType, 205},
+		{"max IVs", Stat{Base: 100, IV: 31}, 100, HardyNature, AttackStatType, 236},
+		{"max EVs", Stat{Base: 100, EV: 252}, 100, HardyNature, AttackStatType, 268},
+		{"partial EVs round down", Stat{Base: 100, EV: 7}, 100, HardyNature, AttackStatType, 206},
+		// Low levels
+		{"level 1", Stat{Base: 100, IV: 31, EV: 252}, 1, HardyNature, AttackStatType, 7},
+		{"level 5", Stat{Base: 49, IV: 15}, 5, HardyNature, DefenseStatType, 10},
+		// Invalid natures are treated as neutral
+		{"no nature", Stat{Base: 90, IV: 31, EV: 252}, 50, Nature(0), SpeedStatType, 142},
+	}
+
+	for _, test := range tests {
+		if got := CalcStat(test.stat, test.level, test.nature, test.statType); got != test.want {
+			t.Errorf("%v: CalcStat(%+v, %v, %v, %v) = %v, want %v",
+				test.name, test.stat, test.level, test.nature.Name(), test.statType, got, test.want)
+		}
+	}
+}
+
+func TestApplyStatStage(t *testing.T) {
+	// The in-battle value of a stat of 100 at each stage
+	tests := []struct {
+		stage int
+		want  int
+	}{
+		{-7, 25},
+		{-6, 25},
+		{-5, 28},
+		{-4, 33},
+		{-3, 40},
+		{-2, 50},
+		{-1, 66},
+		{0, 100},
+		{1, 150},
+		{2, 200},
+		{3, 250},
+		{4, 300},
+		{5, 350},
+		{6, 400},
+		{7, 400},
+	}
+
+	for _, test := range tests {
+		if got := applyStatStage(100, test.stage); got != test.want {
+			t.Errorf("applyStatStage(100, %v) = %v, want %v", test.stage, got, test.want)
+		}
+	}
+}
+
+func TestCalcIBStat(t *testing.T) {
+	// A level 100 Pokemon with a neutral nature and a base 100 stat in every
+	// slot, so every stat is 236 before stages
+	p := Pokemon{
+		Level:     100,
+		Nature:    HardyNature,
+		HP:        Stat{Base: 100, IV: 31},
+		Attack:    Stat{Base: 100, IV: 31},
+		Defense:   Stat{Base: 100, IV: 31},
+		SpAttack:  Stat{Base: 100, IV: 31},
+		SpDefense: Stat{Base: 100, IV: 31},
+		Speed:     Stat{Base: 100, IV: 31}}
+	pBI := PokemonBattleInfo{
+		AttStage:   2,
+		DefStage:   -1,
+		SpAttStage: 6,
+		SpDefStage: -6,
+		SpeedStage: 1}
+
+	tests := []struct {
+		name string
+		calc func(Pokemon, PokemonBattleInfo) int
+		want int
+	}{
+		{"HP", CalcIBHP, 341},
+		{"attack", CalcIBAttack, 472},
+		{"defense", CalcIBDefense, 157},
+		{"special attack", CalcIBSpAtt, 944},
+		{"special defense", CalcIBSpDef, 59},
+		{"speed", CalcIBSpeed, 354},
+	}
+
+	for _, test := range tests {
+		if got := test.calc(p, pBI); got != test.want {
+			t.Errorf("in-battle %v = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCalcIBAccuracyAndEvasion(t *testing.T) {
+	// Accuracy and evasion use thirds instead of halves
+	tests := []struct {
+		stage int
+		want  int
+	}{
+		{-7, 33},
+		{-6, 33},
+		{-5, 37},
+		{-4, 42},
+		{-3, 50},
+		{-2, 60},
+		{-1, 75},
+		{0, 100},
+		{1, 133},
+		{2, 166},
+		{3, 200},
+		{4, 233},
+		{5, 266},
+		{6, 300},
+		{7, 300},
+	}
+
+	for _, test := range tests {
+		pBI := PokemonBattleInfo{AccuracyStage: test.stage, EvasionStage: test.stage}
+		if got := CalcIBAccuracy(Pokemon{}, pBI); got != test.want {
+			t.Errorf("CalcIBAccuracy at stage %v = %v, want %v", test.stage, got, test.want)
+		}
+		if got := CalcIBEvasion(Pokemon{}, pBI); got != test.want {
+			t.Errorf("CalcIBEvasion at stage %v = %v, want %v", test.stage, got, test.want)
+		}
+	}
+}
+
+func TestChangeStatStage(t *testing.T) {
+	tests := []struct {
+		start, change    int
+		wantStage, moved int
+	}{
+		{0, 2, 2, 2},
+		{5, 2, 6, 1},
+		{6, 1, 6, 0},
+		{-5, -3, -6, -1},
+		{-6, 2, -4, 2},
+	}
+
+	for _, test := range tests {
+		pBI := PokemonBattleInfo{SpDefStage: test.start}
+		moved := pBI.ChangeStatStage(SpecialDefenseStatType, test.change)
+		if pBI.SpDefStage != test.wantStage || moved != test.moved {
+			t.Errorf("changing stage %v by %v gave stage %v (moved %v), want %v (moved %v)",
+				test.start, test.change, pBI.SpDefStage, moved, test.wantStage, test.moved)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package pkmn
+
+// TradeOffer is one trainer's side of a trade with another trainer. A trade
+// goes through once both trainers have offered a Pokemon to each other and
+// confirmed.
+type TradeOffer struct {
+	// Partner is the UUID of the trainer being traded with, or an empty string
+	// if the trainer isn't trading.
+	Partner string
+	// Pokemon is the UUID of the party Pokemon being offered.
+	Pokemon string
+	// Confirmed is true once the trainer has agreed to the trade as it
+	// stands.
+	Confirmed bool
+}
+
+// Trading returns true if the trainer has offered a Pokemon to someone.
+func (t *Trainer) Trading() bool {
+	return t.Trade.Partner != ""
+}
+
+// CancelTrade withdraws the trainer's side of any trade they're in.
+func (t *Trainer) CancelTrade() {
+	t.Trade = TradeOffer{}
+}
+
+// TradeAgreed returns true if the two trainers have offered Pokemon to each
+// other.
+func TradeAgreed(t1, t2 *Trainer) bool {
+	return t1.Trade.Partner == t2.UUID && t2.Trade.Partner == t1.UUID &&
+		t1.Trade.Pokemon != "" && t2.Trade.Pokemon != ""
+}
+
+// TradeReady returns true if the two trainers have offered Pokemon to each
+// other and both confirmed the trade.
+func TradeReady(t1, t2 *Trainer) bool {
+	return TradeAgreed(t1, t2) && t1.Trade.Confirmed && t2.Trade.Confirmed
+}
+
+// MarkTraded records that the Pokemon is being traded away by the given
+// trainer. The first trainer to trade a Pokemon away is its original trainer.
+func (p *Pokemon) MarkTraded(from *Trainer) {
+	if p.OriginalTrainer == "" {
+		p.OriginalTrainer = from.UUID
+		p.OriginalTrainerName = from.Name
+	}
+}
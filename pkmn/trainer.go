@@ -1,10 +1,6 @@
 package pkmn
 
-import (
-	"math/rand"
-
-	"github.com/pkg/errors"
-)
+import "time"
 
 const MaxPartySize = 6
 
@@ -16,6 +12,8 @@ const (
 	WaitingTrainerMode
 	BattlingTrainerMode
 	ForgetMoveTrainerMode
+	EvolvingTrainerMode
+	ReleasingTrainerMode
 )
 
 // TrainerType represents different classes of trainers.
@@ -31,6 +29,23 @@ const (
 	GymLeaderTrainerType
 )
 
+// BattleStrategy is the way a bot trainer decides what to do in battle.
+type BattleStrategy int
+
+const (
+	// DefaultBattleStrategy uses whichever strategy suits the trainer's type.
+	DefaultBattleStrategy BattleStrategy = iota
+	// RandomBattleStrategy uses a random move every turn.
+	RandomBattleStrategy
+	// GreedyBattleStrategy uses the move expected to do the most damage,
+	// switching out or healing when the active Pokemon is in a bad spot.
+	GreedyBattleStrategy
+	// MinimaxBattleStrategy plays out every action against every move the
+	// opponent could reply with and picks the action with the best worst
+	// case.
+	MinimaxBattleStrategy
+)
+
 // Trainer is a named person (human or otherwise) that owns Pokemon and can
 // engage in battles and other activities.
 type Trainer struct {
@@ -38,7 +53,12 @@ type Trainer struct {
 	Name string
 	Mode TrainerMode
 	Type TrainerType
+	// Strategy is how the trainer picks actions in battle. It only matters
+	// for bot trainers.
+	Strategy BattleStrategy
 
+	// The encounter levels are left over from before areas were unlocked by
+	// badges. They're no longer used.
 	KantoBadges         int
 	KantoEncounterLevel int
 
@@ -57,23 +77,34 @@ type Trainer struct {
 	KalosBadges         int
 	KalosEncounterLevel int
 
+	// Region and Area are where the trainer finds wild Pokemon. An empty
+	// area means the trainer hasn't traveled anywhere yet.
+	Region Region
+	Area   string
+
 	Wins   int
 	Losses int
-}
 
-func (t *Trainer) PickMove(moves []int, moveCnt int) (int, error) {
-	switch t.Type {
-	case HumanTrainerType:
-		// Human trainers can't have their moves picked automatically
-		return -1, errors.New("attempt to have a human trainer's move picked automatically")
-	case WildTrainerType:
-		// Wild Pokemon get their moves picked randomly
-		return moves[rand.Intn(moveCnt)], nil
-	case GymLeaderTrainerType:
-		// Gym leaders pick their Pokemon's moves randomly. This will probably
-		// get more inspiring in the future
-		return moves[rand.Intn(moveCnt)], nil
-	default:
-		panic("unimplemented trainer type")
-	}
+	// Money is the trainer's balance. Every change to it should be recorded
+	// with a LedgerEntry.
+	Money int
+
+	Bag []BagEntry
+
+	// LastWalk is when the trainer last took their party for a walk.
+	LastWalk time.Time
+	// LastHeal is when the trainer last had their party healed at the
+	// Pokemon Center.
+	LastHeal time.Time
+
+	// PendingRelease is the UUID of the party Pokemon the trainer is
+	// deciding whether to release, or an empty string if they aren't.
+	PendingRelease string
+
+	// Trade is the trainer's side of the trade they're in, if any.
+	Trade TradeOffer
+
+	// Pokedex is the record of every species the trainer has seen and
+	// caught.
+	Pokedex Pokedex
 }
@@ -0,0 +1,142 @@
+package pkmn
+
+// Weather represents the weather conditions of a battle. Weather affects the
+// Pokemon fighting in it, usually through their abilities and moves.
+type Weather int
+
+const (
+	NoWeather Weather = iota
+	RainWeather
+	SunWeather
+	SandstormWeather
+	HailWeather
+)
+
+// WeatherDuration is the number of turns weather lasts once it starts.
+const WeatherDuration = 5
+
+// Name returns the lowercase name of the weather.
+func (w Weather) Name() string {
+	switch w {
+	case RainWeather:
+		return "rain"
+	case SunWeather:
+		return "harsh sunlight"
+	case SandstormWeather:
+		return "sandstorm"
+	case HailWeather:
+		return "hail"
+	default:
+		return "clear"
+	}
+}
+
+// weatherMoves maps the PokeAPI names of moves that start weather to the
+// weather they start.
+var weatherMoves = map[string]Weather{
+	"rain-dance": RainWeather,
+	"sunny-day":  SunWeather,
+	"sandstorm":  SandstormWeather,
+	"hail":       HailWeather}
+
+// StartWeather starts the given weather in the battle for WeatherDuration
+// turns. It returns false if that weather was already in effect, in which
+// case nothing changes.
+func (b *Battle) StartWeather(w Weather) bool {
+	if b.Weather == w {
+		return false
+	}
+	b.Weather = w
+	b.WeatherTurns = WeatherDuration
+	return true
+}
+
+// AdvanceWeather counts down the turns left in the current weather, clearing
+// the weather once it has run out. It returns the weather that ended, or
+// NoWeather if the weather is still going or there was none to begin with.
+func (b *Battle) AdvanceWeather() Weather {
+	if b.Weather == NoWeather {
+		return NoWeather
+	}
+
+	b.WeatherTurns--
+	if b.WeatherTurns > 0 {
+		return NoWeather
+	}
+
+	ended := b.Weather
+	b.Weather = NoWeather
+	b.WeatherTurns = 0
+	return ended
+}
+
+// weatherDamageModifier returns the multiplier the weather applies to the
+// damage of the move. Rain powers up water moves and weakens fire moves, and
+// harsh sunlight does the opposite.
+func weatherDamageModifier(weather Weather, move Move) float64 {
+	switch {
+	case weather == RainWeather && move.Type == "water":
+		return 1.5
+	case weather == RainWeather && move.Type == "fire":
+		return 0.5
+	case weather == SunWeather && move.Type == "fire":
+		return 1.5
+	case weather == SunWeather && move.Type == "water":
+		return 0.5
+	default:
+		return 1.0
+	}
+}
+
+// weatherSpDefModifier returns the multiplier the weather applies to the
+// special defense of the Pokemon. Rock type Pokemon get a boost in a
+// sandstorm.
+func weatherSpDefModifier(weather Weather, p *Pokemon) float64 {
+	if weather == SandstormWeather && (p.Type1 == "rock" || p.Type2 == "rock") {
+		return 1.5
+	}
+	return 1.0
+}
+
+// hasType returns true if the Pokemon has any of the given types.
+func hasType(p *Pokemon, types ...string) bool {
+	for _, t := range types {
+		if p.Type1 == t || p.Type2 == t {
+			return true
+		}
+	}
+	return false
+}
+
+// RunWeatherDamage deals the end of turn damage of the battle's weather to the
+// Pokemon and returns the damage dealt. Sandstorms hurt everything but rock,
+// ground and steel types, and hail hurts everything but ice types. Both deal
+// a sixteenth of the Pokemon's max HP.
+func RunWeatherDamage(b *Battle, p *Pokemon, pBI *PokemonBattleInfo) int {
+	if pBI.CurrHP <= 0 {
+		return 0
+	}
+
+	switch b.Weather {
+	case SandstormWeather:
+		if hasType(p, "rock", "ground", "steel") {
+			return 0
+		}
+	case HailWeather:
+		if hasType(p, "ice") {
+			return 0
+		}
+	default:
+		return 0
+	}
+
+	damage := CalcIBHP(*p, *pBI) / 16
+	if damage < 1 {
+		damage = 1
+	}
+	pBI.CurrHP -= damage
+	if pBI.CurrHP < 0 {
+		pBI.CurrHP = 0
+	}
+	return damage
+}
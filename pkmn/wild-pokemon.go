@@ -1,105 +1,223 @@
 package pkmn
 
 import (
-	"math/rand"
-	"time"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
-// WildEntry describtes the encounter details of the Pokemon as indicated by
-// its national Pokedex ID. The encounter rate should be some value between
-// 1 and 100.
+// WildEntry describes the encounter details of the Pokemon as indicated by
+// its national Pokedex ID. Wild Pokemon are found at a random level between
+// the minimum and maximum level, inclusive.
 type WildEntry struct {
-	ID          int
-	Probability int
-	MedianLevel int
+	ID int
+	// Weight is how likely the Pokemon is to be found compared to the other
+	// Pokemon in its area. Rare Pokemon have small weights.
+	Weight   int
+	MinLevel int
+	MaxLevel int
+	// Time is the part of the day the Pokemon can be found in. Pokemon with
+	// AnyTimeOfDay can be found at all times.
+	Time TimeOfDay
 }
 
-var kantoWilds = [][]WildEntry{
-	{{16, 100, 3}, {19, 100, 3}, {21, 100, 3}, {29, 40, 3}, {32, 40, 3}, {56, 30, 3}, {10, 100, 3}, {13, 90, 3}, {11, 70, 3}, {14, 70, 3}, {25, 10, 3}}}
-
-var johtoWilds = [][]WildEntry{
-	{{161, 100, 5}, {163, 90, 5}, {187, 30, 5}, {60, 30, 5}, {165, 70, 5}, {167, 70, 5}, {69, 50, 5}, {92, 5, 5}, {206, 20, 5}, {216, 20, 5}}}
+// foundAt returns true if the wild Pokemon can be found at the given time of
+// day.
+func (w WildEntry) foundAt(tod TimeOfDay) bool {
+	return w.Time == AnyTimeOfDay || tod == AnyTimeOfDay || w.Time == tod
+}
 
-var hoennWilds = [][]WildEntry{
-	{{261, 100, 6}, {263, 100, 6}, {265, 100, 6}, {270, 70, 6}, {273, 20, 6}, {280, 10, 6}, {283, 10, 6}, {278, 70, 6}}}
+// Area is a route, cave or other place in a region where wild Pokemon live.
+type Area struct {
+	// Name is the lowercase, hyphenated name of the area, like "mt-moon".
+	Name string
+	// Badges is the number of badges from the area's region a trainer needs
+	// before they can travel there.
+	Badges int
+	Wilds  []WildEntry
+}
 
-var sinnohWilds = [][]WildEntry{
-	{{396, 100, 7}, {399, 100, 7}, {401, 50, 7}, {403, 70, 7}, {406, 70, 7}, {63, 10, 7}, {417, 40, 7}}}
+// regionAreas contains every area in each region, in the order that trainers
+// unlock them. It's empty until the encounter tables are loaded with
+// SetEncounterTables.
+var regionAreas = map[Region][]Area{}
+
+// ValidateEncounterTables checks that the given encounter tables have areas
+// for every region and that every area can be traveled to and has wild
+// Pokemon at all times of day.
+func ValidateEncounterTables(tables map[Region][]Area) error {
+	for r := Region(0); r < Region(RegionCount); r++ {
+		areas := tables[r]
+		if len(areas) == 0 {
+			return errors.Errorf("region %v has no areas", r.Name())
+		}
+		if areas[0].Badges != 0 {
+			return errors.Errorf("the first area in %v must need no badges", r.Name())
+		}
 
-var unovaWilds = [][]WildEntry{
-	{{504, 100, 8}, {506, 100, 8}, {509, 80, 8}, {531, 20, 8}, {550, 40, 8}, {517, 10, 8}, {519, 100, 8}}}
+		names := make(map[string]bool)
+		prevBadges := 0
+		for _, area := range areas {
+			if area.Name == "" || area.Name != strings.ToLower(area.Name) || strings.ContainsAny(area.Name, " \t") {
+				return errors.Errorf("area name '%v' in %v must be lowercase and hyphenated", area.Name, r.Name())
+			}
+			if names[area.Name] {
+				return errors.Errorf("area %v appears in %v more than once", area.Name, r.Name())
+			}
+			names[area.Name] = true
+
+			if area.Badges < prevBadges || area.Badges > len(GymLeaders(r)) {
+				return errors.Errorf("area %v in %v needs an invalid number of badges %v", area.Name, r.Name(), area.Badges)
+			}
+			prevBadges = area.Badges
+
+			if err := validateWilds(area.Wilds); err != nil {
+				return errors.Wrapf(err, "in area %v in %v", area.Name, r.Name())
+			}
+		}
+	}
 
-var kalosWilds = [][]WildEntry{
-	{{661, 100, 9}, {659, 100, 9}, {664, 100, 9}, {511, 10, 9}, {513, 10, 9}, {515, 10, 9}, {25, 20, 9}, {298, 30, 9}, {412, 10, 9}}}
+	for r := range tables {
+		if r < 0 || r >= Region(RegionCount) {
+			return errors.Errorf("encounter tables contain unknown region %v", int(r))
+		}
+	}
 
-// AvailableWildPokemon returns a list of all Pokemon that the given trainer
-// may encounter split up by region.
-func AvailableWildPokemon(t Trainer) [][]WildEntry {
-	var wilds [][]WildEntry
+	return nil
+}
 
-	for i := 0; i < t.KantoEncounterLevel; i++ {
-		wilds = append(wilds, kantoWilds[i])
+// validateWilds checks that each wild Pokemon in an area is a real species
+// found at valid levels, and that some Pokemon can be found at every time of
+// day.
+func validateWilds(wilds []WildEntry) error {
+	for _, wild := range wilds {
+		if wild.ID < 1 || wild.ID > NationalDexSize {
+			return errors.Errorf("invalid species ID %v", wild.ID)
+		}
+		if wild.Weight <= 0 {
+			return errors.Errorf("species %v has a non-positive weight %v", wild.ID, wild.Weight)
+		}
+		if wild.MinLevel < 1 || wild.MaxLevel > 100 || wild.MinLevel > wild.MaxLevel {
+			return errors.Errorf("species %v has an invalid level range %v-%v", wild.ID, wild.MinLevel, wild.MaxLevel)
+		}
+		if wild.Time != AnyTimeOfDay && wild.Time != DayTimeOfDay && wild.Time != NightTimeOfDay {
+			return errors.Errorf("species %v has an invalid time of day %v", wild.ID, int(wild.Time))
+		}
 	}
-	for i := 0; i < t.JohtoEncounterLevel; i++ {
-		wilds = append(wilds, johtoWilds[i])
+
+	for _, tod := range []TimeOfDay{DayTimeOfDay, NightTimeOfDay} {
+		if totalWeight(wilds, tod) == 0 {
+			return errors.Errorf("no wild Pokemon can be found at %v", tod.Name())
+		}
 	}
-	for i := 0; i < t.HoennEncounterLevel; i++ {
-		wilds = append(wilds, hoennWilds[i])
+
+	return nil
+}
+
+// SetEncounterTables validates the given encounter tables and, if they're
+// valid, makes them the areas and wild Pokemon trainers will find.
+func SetEncounterTables(tables map[Region][]Area) error {
+	if err := ValidateEncounterTables(tables); err != nil {
+		return err
 	}
-	for i := 0; i < t.SinnohEncounterLevel; i++ {
-		wilds = append(wilds, sinnohWilds[i])
+	regionAreas = tables
+	return nil
+}
+
+// Areas returns every area in the region, in the order trainers unlock them.
+func Areas(r Region) []Area {
+	return regionAreas[r]
+}
+
+// FindArea returns the area in the region with the given name, or false if
+// there is no such area.
+func FindArea(r Region, name string) (Area, bool) {
+	for _, area := range regionAreas[r] {
+		if area.Name == name {
+			return area, true
+		}
 	}
-	for i := 0; i < t.UnovaEncounterLevel; i++ {
-		wilds = append(wilds, unovaWilds[i])
+	return Area{}, false
+}
+
+// unlockedAreaCount returns how many of the region's areas a trainer with the
+// given number of the region's badges can travel to.
+func unlockedAreaCount(r Region, badges int) int {
+	count := 0
+	for _, area := range regionAreas[r] {
+		if area.Badges <= badges {
+			count++
+		}
 	}
-	for i := 0; i < t.KalosEncounterLevel; i++ {
-		wilds = append(wilds, kalosWilds[i])
+	return count
+}
+
+// UnlockedAreas returns the areas in the region the trainer can travel to,
+// which are the ones their badges from the region unlock.
+func (t *Trainer) UnlockedAreas(r Region) []Area {
+	return regionAreas[r][:unlockedAreaCount(r, t.Badges(r))]
+}
+
+// CanTravel returns true if the trainer has unlocked the given area.
+func (t *Trainer) CanTravel(r Region, area Area) bool {
+	for _, unlocked := range t.UnlockedAreas(r) {
+		if unlocked.Name == area.Name {
+			return true
+		}
 	}
+	return false
+}
 
-	return wilds
+// Travel sends the trainer to the given area, where they'll find wild Pokemon
+// from then on.
+func (t *Trainer) Travel(r Region, area Area) {
+	t.Region = r
+	t.Area = area.Name
 }
 
-// RandomWildPokemon picks a random wild Pokemon from the list of
-// possibilities by first picking a region and random, then picking a Pokemon
-// from that region based on the probablities of each.
-func RandomWildPokemon(wilds [][]WildEntry) WildEntry {
-	// Seed the random number generator
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	// Randomly decide which region the Pokemon will be from
-	region := rng.Intn(len(wilds))
-	regionWilds := wilds[region]
-
-	// Sum up the full probability of all Pokemon in the region
-	var probabilitySum int
-	for _, wild := range regionWilds {
-		probabilitySum += wild.Probability
+// CurrentArea returns the region and area the trainer finds wild Pokemon in.
+// Trainers that haven't traveled anywhere yet are in the first area of Kanto.
+func (t *Trainer) CurrentArea() (Region, Area) {
+	if area, ok := FindArea(t.Region, t.Area); ok {
+		return t.Region, area
 	}
+	return KantoRegion, regionAreas[KantoRegion][0]
+}
 
-	// Randomly decide the wild Pokemon
-	num := rng.Intn(probabilitySum)
-	var currProbability int
-	for _, wild := range regionWilds {
-		currProbability += wild.Probability
-		if currProbability >= num {
-			return wild
+// totalWeight returns the sum of the weights of every wild Pokemon that can be
+// found at the given time of day.
+func totalWeight(wilds []WildEntry, tod TimeOfDay) int {
+	var sum int
+	for _, wild := range wilds {
+		if wild.foundAt(tod) {
+			sum += wild.Weight
 		}
 	}
-
-	panic("A random wild Pokemon was not chosen!")
+	return sum
 }
 
-// CatchRate returns the catch rate of the Pokemon.
-func CatchRate(p Pokemon, pBI PokemonBattleInfo) float64 {
-	hp := float64(CalcOOBHP(p.HP, p))
-	var bonus float64
-	switch pBI.Ailment {
-	default:
-		bonus = 1.0
-	case SleepAilment, FreezeAilment:
-		bonus = 2.0
-	case ParalysisAilment, PoisonAilment, BurnAilment:
-		bonus = 1.5
+// RandomWildPokemon picks a random wild Pokemon from the ones in the area that
+// can be found at the given time of day, based on their weights, along with
+// the level it's found at.
+func RandomWildPokemon(rng RNG, area Area, tod TimeOfDay) (WildEntry, int) {
+	sum := totalWeight(area.Wilds, tod)
+	if sum <= 0 {
+		panic("no wild Pokemon can be found in " + area.Name)
+	}
+
+	// Each Pokemon owns the numbers from the previous Pokemon's cumulative
+	// weight up to, but not including, its own
+	num := rng.Intn(sum)
+	var cumulative int
+	for _, wild := range area.Wilds {
+		if !wild.foundAt(tod) {
+			continue
+		}
+		cumulative += wild.Weight
+		if num < cumulative {
+			return wild, wild.MinLevel + rng.Intn(wild.MaxLevel-wild.MinLevel+1)
+		}
 	}
-	return (((3.0*hp - (2.0 * float64(pBI.CurrHP))) * float64(p.CatchRate)) / (3.0 * hp)) * bonus
+
+	panic("A random wild Pokemon was not chosen!")
 }
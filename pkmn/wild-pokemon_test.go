@@ -0,0 +1,151 @@
+package pkmn
+
+import "testing"
+
+// scriptedRNG is an RNG that returns a fixed series of numbers. It records the
+// bounds it was asked for.
+type scriptedRNG struct {
+	nums   []int
+	bounds []int
+}
+
+func (r *scriptedRNG) Intn(n int) int {
+	r.bounds = append(r.bounds, n)
+	if len(r.nums) == 0 {
+		panic("scripted RNG ran out of numbers")
+	}
+	num := r.nums[0]
+	r.nums = r.nums[1:]
+	if num < 0 || num >= n {
+		panic("scripted number out of range")
+	}
+	return num
+}
+
+func TestRandomWildPokemon(t *testing.T) {
+	// By day, numbers 0-9 belong to Pidgey and 10 belongs to Ledyba. By
+	// night, 0-9 belong to Pidgey and 10-14 belong to Hoothoot.
+	area := Area{
+		Name: "route-30",
+		Wilds: []WildEntry{
+			{ID: 16, Weight: 10, MinLevel: 3, MaxLevel: 3},
+			{ID: 163, Weight: 5, MinLevel: 2, MaxLevel: 6, Time: NightTimeOfDay},
+			{ID: 165, Weight: 1, MinLevel: 4, MaxLevel: 4, Time: DayTimeOfDay}}}
+
+	tests := []struct {
+		name      string
+		tod       TimeOfDay
+		nums      []int
+		wantID    int
+		wantLevel int
+		wantSum   int
+	}{
+		{"first number", DayTimeOfDay, []int{0, 0}, 16, 3, 11},
+		{"last number of the first Pokemon", DayTimeOfDay, []int{9, 0}, 16, 3, 11},
+		{"first number of the second Pokemon", DayTimeOfDay, []int{10, 0}, 165, 4, 11},
+		{"skipped Pokemon by night", NightTimeOfDay, []int{9, 0}, 16, 3, 15},
+		{"first number at night", NightTimeOfDay, []int{10, 0}, 163, 2, 15},
+		{"last number at night", NightTimeOfDay, []int{14, 4}, 163, 6, 15},
+	}
+
+	for _, test := range tests {
+		rng := &scriptedRNG{nums: test.nums}
+		wild, level := RandomWildPokemon(rng, area, test.tod)
+		if wild.ID != test.wantID || level != test.wantLevel {
+			t.Errorf("%v: got species %v at level %v, want %v at level %v",
+				test.name, wild.ID, level, test.wantID, test.wantLevel)
+		}
+		if len(rng.bounds) == 0 || rng.bounds[0] != test.wantSum {
+			t.Errorf("%v: picked out of %v, want out of the total weight %v", test.name, rng.bounds, test.wantSum)
+		}
+	}
+}
+
+// validEncounterTables returns encounter tables with one area in every
+// region.
+func validEncounterTables() map[Region][]Area {
+	tables := make(map[Region][]Area)
+	for r := Region(0); r < Region(RegionCount); r++ {
+		tables[r] = []Area{{
+			Name:  "route-1",
+			Wilds: []WildEntry{{ID: 16, Weight: 1, MinLevel: 2, MaxLevel: 4}}}}
+	}
+	return tables
+}
+
+func TestValidateEncounterTables(t *testing.T) {
+	if err := ValidateEncounterTables(validEncounterTables()); err != nil {
+		t.Fatalf("valid encounter tables were rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(tables map[Region][]Area)
+	}{
+		{"missing region", func(tables map[Region][]Area) {
+			delete(tables, JohtoRegion)
+		}},
+		{"unknown region", func(tables map[Region][]Area) {
+			tables[Region(RegionCount)] = tables[KantoRegion]
+		}},
+		{"first area needs badges", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Badges = 1
+		}},
+		{"empty name", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Name = ""
+		}},
+		{"uppercase name", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Name = "Route-1"
+		}},
+		{"name with spaces", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Name = "route 1"
+		}},
+		{"duplicate name", func(tables map[Region][]Area) {
+			tables[KantoRegion] = append(tables[KantoRegion], tables[KantoRegion][0])
+		}},
+		{"decreasing badges", func(tables map[Region][]Area) {
+			tables[KantoRegion] = append(tables[KantoRegion],
+				Area{Name: "route-2", Badges: 1, Wilds: tables[KantoRegion][0].Wilds},
+				Area{Name: "route-3", Badges: 0, Wilds: tables[KantoRegion][0].Wilds})
+		}},
+		{"more badges than gym leaders", func(tables map[Region][]Area) {
+			tables[KantoRegion] = append(tables[KantoRegion],
+				Area{Name: "route-2", Badges: len(GymLeaders(KantoRegion)) + 1, Wilds: tables[KantoRegion][0].Wilds})
+		}},
+		{"no species", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].ID = 0
+		}},
+		{"species past the national dex", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].ID = NationalDexSize + 1
+		}},
+		{"zero weight", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].Weight = 0
+		}},
+		{"level zero", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].MinLevel = 0
+		}},
+		{"level past 100", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].MaxLevel = 101
+		}},
+		{"backwards levels", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].MinLevel = 5
+		}},
+		{"invalid time of day", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].Time = TimeOfDay(3)
+		}},
+		{"nothing at night", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds[0].Time = DayTimeOfDay
+		}},
+		{"nothing at all", func(tables map[Region][]Area) {
+			tables[KantoRegion][0].Wilds = nil
+		}},
+	}
+
+	for _, test := range tests {
+		tables := validEncounterTables()
+		test.change(tables)
+		if err := ValidateEncounterTables(tables); err == nil {
+			t.Errorf("%v: encounter tables were accepted, want an error", test.name)
+		}
+	}
+}
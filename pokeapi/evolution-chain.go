@@ -0,0 +1,217 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+	"golang.org/x/net/context"
+)
+
+// namedResource is a reference to another PokeAPI resource.
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// EvolutionDetail describes the conditions a Pokemon has to meet to evolve
+// into a species.
+type EvolutionDetail struct {
+	Trigger               namedResource  `json:"trigger"`
+	MinLevel              *int           `json:"min_level"`
+	Item                  *namedResource `json:"item"`
+	HeldItem              *namedResource `json:"held_item"`
+	KnownMove             *namedResource `json:"known_move"`
+	KnownMoveType         *namedResource `json:"known_move_type"`
+	Location              *namedResource `json:"location"`
+	PartySpecies          *namedResource `json:"party_species"`
+	PartyType             *namedResource `json:"party_type"`
+	TradeSpecies          *namedResource `json:"trade_species"`
+	Gender                *int           `json:"gender"`
+	MinHappiness          *int           `json:"min_happiness"`
+	MinAffection          *int           `json:"min_affection"`
+	MinBeauty             *int           `json:"min_beauty"`
+	RelativePhysicalStats *int           `json:"relative_physical_stats"`
+	TimeOfDay             string         `json:"time_of_day"`
+	NeedsOverworldRain    bool           `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool           `json:"turn_upside_down"`
+}
+
+// ChainLink is a single species in an evolution chain along with the species
+// it can evolve into.
+type ChainLink struct {
+	Species          namedResource     `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionChain describes a PokeAPI evolution chain, which is the family of
+// species that evolve into each other.
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// FetchEvolutionChain queries PokeAPI directly for the evolution chain with
+// the given id. This function should be avoided in favor of using a Fetcher.
+func FetchEvolutionChain(id int, client messaging.Client) (EvolutionChain, error) {
+	// Query the API
+	resp, err := client.Get(apiURL + evolutionChainEP + strconv.Itoa(id) + "/")
+	if err != nil {
+		return EvolutionChain{}, errors.Wrap(err, "fetching an evolution chain")
+	}
+	defer resp.Body.Close()
+
+	// Read the response data
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return EvolutionChain{}, errors.Wrap(err, "reading evolution chain data")
+	}
+
+	// Unmarshal the response into an evolution chain object
+	var ec EvolutionChain
+	err = json.Unmarshal(data, &ec)
+	if err != nil {
+		return EvolutionChain{}, errors.Wrap(err, "parsing evolution chain data")
+	}
+
+	return ec, nil
+}
+
+// findLink returns the link in the chain for the species with the given ID,
+// or false if the species isn't part of the chain.
+func (link ChainLink) findLink(speciesID int) (ChainLink, bool, error) {
+	id, err := idFromURL(link.Species.URL)
+	if err != nil {
+		return ChainLink{}, false, err
+	}
+	if id == speciesID {
+		return link, true, nil
+	}
+
+	for _, next := range link.EvolvesTo {
+		found, ok, err := next.findLink(speciesID)
+		if err != nil || ok {
+			return found, ok, err
+		}
+	}
+	return ChainLink{}, false, nil
+}
+
+// hasUnsupportedConditions returns true if the evolution depends on
+// something that isn't modeled, like the Pokemon's location or the weather.
+func (ed EvolutionDetail) hasUnsupportedConditions() bool {
+	return ed.KnownMove != nil || ed.KnownMoveType != nil || ed.Location != nil ||
+		ed.PartySpecies != nil || ed.PartyType != nil || ed.TradeSpecies != nil ||
+		ed.Gender != nil || ed.MinAffection != nil || ed.MinBeauty != nil ||
+		ed.RelativePhysicalStats != nil || ed.NeedsOverworldRain || ed.TurnUpsideDown
+}
+
+// toEvolution converts the evolution detail into an evolution into the
+// species with the given ID. False is returned if the evolution has a trigger
+// or condition that isn't supported.
+func (ed EvolutionDetail) toEvolution(speciesID int) (pkmn.Evolution, bool) {
+	if ed.hasUnsupportedConditions() {
+		return pkmn.Evolution{}, false
+	}
+
+	evo := pkmn.Evolution{SpeciesID: speciesID}
+
+	switch ed.Trigger.Name {
+	case "level-up":
+		evo.Trigger = pkmn.LevelUpEvolutionTrigger
+	case "use-item":
+		evo.Trigger = pkmn.UseItemEvolutionTrigger
+		if ed.Item == nil {
+			return pkmn.Evolution{}, false
+		}
+		item, ok := pkmn.NameToItem(ed.Item.Name)
+		if !ok {
+			return pkmn.Evolution{}, false
+		}
+		evo.Item = item
+	case "trade":
+		evo.Trigger = pkmn.TradeEvolutionTrigger
+	default:
+		return pkmn.Evolution{}, false
+	}
+
+	// Only trade evolutions may need a held item, which gets used up as the
+	// Pokemon changes hands
+	if ed.HeldItem != nil {
+		heldItem, ok := pkmn.NameToItem(ed.HeldItem.Name)
+		if !ok || evo.Trigger != pkmn.TradeEvolutionTrigger {
+			return pkmn.Evolution{}, false
+		}
+		evo.HeldItem = heldItem
+	}
+	if ed.MinLevel != nil {
+		evo.MinLevel = *ed.MinLevel
+	}
+	if ed.MinHappiness != nil {
+		evo.MinFriendship = *ed.MinHappiness
+	}
+	switch ed.TimeOfDay {
+	case "":
+		evo.TimeOfDay = pkmn.AnyTimeOfDay
+	case "day":
+		evo.TimeOfDay = pkmn.DayTimeOfDay
+	case "night":
+		evo.TimeOfDay = pkmn.NightTimeOfDay
+	default:
+		return pkmn.Evolution{}, false
+	}
+
+	// A level up evolution with no conditions would happen right away
+	if evo.Trigger == pkmn.LevelUpEvolutionTrigger && evo.MinLevel == 0 &&
+		evo.MinFriendship == 0 && evo.TimeOfDay == pkmn.AnyTimeOfDay {
+		return pkmn.Evolution{}, false
+	}
+
+	return evo, true
+}
+
+// FetchEvolutions returns all the ways the Pokemon species with the given ID
+// can evolve. Evolutions with conditions that aren't supported are left out.
+func FetchEvolutions(ctx context.Context, client messaging.Client, fetcher Fetcher, speciesID int) ([]pkmn.Evolution, error) {
+	// Find the evolution chain the species is a part of
+	species, err := fetcher.FetchPokemonSpecies(ctx, client, speciesID)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := idFromURL(species.EvolutionChain.URL)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := fetcher.FetchEvolutionChain(ctx, client, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	link, ok, err := chain.Chain.findLink(speciesID)
+	if err != nil {
+		return nil, errors.Wrap(err, "searching an evolution chain")
+	}
+	if !ok {
+		return nil, errors.Errorf("species %v is not in its own evolution chain", speciesID)
+	}
+
+	// Collect the evolutions into the species the Pokemon evolves into
+	var evolutions []pkmn.Evolution
+	for _, next := range link.EvolvesTo {
+		nextID, err := idFromURL(next.Species.URL)
+		if err != nil {
+			return nil, err
+		}
+		for _, ed := range next.EvolutionDetails {
+			if evo, ok := ed.toEvolution(nextID); ok {
+				evolutions = append(evolutions, evo)
+			}
+		}
+	}
+
+	return evolutions, nil
+}
@@ -15,6 +15,8 @@ type Fetcher interface {
 	FetchPokemon(ctx context.Context, client messaging.Client, id int) (Pokemon, error)
 	FetchMove(ctx context.Context, client messaging.Client, id int) (Move, error)
 	FetchPokemonSpecies(ctx context.Context, client messaging.Client, id int) (PokemonSpecies, error)
+	FetchEvolutionChain(ctx context.Context, client messaging.Client, id int) (EvolutionChain, error)
+	FetchPokemonForm(ctx context.Context, client messaging.Client, id int) (PokemonForm, error)
 }
 
 var registered map[string]Fetcher
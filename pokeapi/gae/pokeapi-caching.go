@@ -190,6 +190,112 @@ func (f GAEFetcher) FetchPokemonSpecies(ctx context.Context, client messaging.Cl
 	return pokemonSpecies, nil
 }
 
+// FetchEvolutionChain wraps around the FetchEvolutionChain method provided by
+// the PokeAPI package and provides in-memory caching. If the evolution chain
+// is not cached, it will ask PokeAPI to request the data. If either that
+// request or the cache operation fails, an error is returned.
+func (f GAEFetcher) FetchEvolutionChain(ctx context.Context, client messaging.Client, id int) (pokeapi.EvolutionChain, error) {
+	cacheKey := "pokeapi.evolutionChain." + strconv.Itoa(id)
+
+	// Try the cache for the evolution chain
+	item, err := memcache.Get(ctx, cacheKey)
+
+	if err == memcache.ErrCacheMiss {
+		// The evolution chain is not in the cache, so we have to ask PokeAPI
+
+		evolutionChain, err := pokeapi.FetchEvolutionChain(id, client)
+		if err != nil {
+			return pokeapi.EvolutionChain{}, err
+		}
+		// Encode the evolution chain structure as a gob
+		buf := &bytes.Buffer{}
+		enc := gob.NewEncoder(buf)
+		err = enc.Encode(evolutionChain)
+		if err != nil {
+			return pokeapi.EvolutionChain{}, err
+		}
+
+		// Add the data to the cache
+		cacheItem := &memcache.Item{
+			Key:   cacheKey,
+			Value: buf.Bytes()}
+		if err := memcache.Add(ctx, cacheItem); err == memcache.ErrNotStored {
+			// Another request may have beaten us to the punch on caching this item. Not a big deal
+			log.Infof(ctx, "attempted to cache %s when it already exists", cacheKey)
+		} else if err != nil {
+			return pokeapi.EvolutionChain{}, err
+		}
+
+		return evolutionChain, nil
+	} else if err != nil {
+		// Some miscellaneous cache error occurred
+		return pokeapi.EvolutionChain{}, err
+	}
+
+	// The evolution chain is in the cache. Decode it from a gob
+	var evolutionChain pokeapi.EvolutionChain
+	buf := bytes.NewBuffer(item.Value)
+	dec := gob.NewDecoder(buf)
+	err = dec.Decode(&evolutionChain)
+	if err != nil {
+		return pokeapi.EvolutionChain{}, err
+	}
+	return evolutionChain, nil
+}
+
+// FetchPokemonForm wraps around the FetchPokemonForm method provided by the
+// PokeAPI package and provides in-memory caching. If the Pokemon form is not
+// cached, it will ask PokeAPI to request the data. If either that request or
+// the cache operation fails, an error is returned.
+func (f GAEFetcher) FetchPokemonForm(ctx context.Context, client messaging.Client, id int) (pokeapi.PokemonForm, error) {
+	cacheKey := "pokeapi.pokemonForm." + strconv.Itoa(id)
+
+	// Try the cache for the Pokemon form
+	item, err := memcache.Get(ctx, cacheKey)
+
+	if err == memcache.ErrCacheMiss {
+		// The Pokemon form is not in the cache, so we have to ask PokeAPI
+
+		pokemonForm, err := pokeapi.FetchPokemonForm(id, client)
+		if err != nil {
+			return pokeapi.PokemonForm{}, err
+		}
+		// Encode the Pokemon form structure as a gob
+		buf := &bytes.Buffer{}
+		enc := gob.NewEncoder(buf)
+		err = enc.Encode(pokemonForm)
+		if err != nil {
+			return pokeapi.PokemonForm{}, err
+		}
+
+		// Add the data to the cache
+		cacheItem := &memcache.Item{
+			Key:   cacheKey,
+			Value: buf.Bytes()}
+		if err := memcache.Add(ctx, cacheItem); err == memcache.ErrNotStored {
+			// Another request may have beaten us to the punch on caching this item. Not a big deal
+			log.Infof(ctx, "attempted to cache %s when it already exists", cacheKey)
+		} else if err != nil {
+			return pokeapi.PokemonForm{}, err
+		}
+
+		return pokemonForm, nil
+	} else if err != nil {
+		// Some miscellaneous cache error occurred
+		return pokeapi.PokemonForm{}, err
+	}
+
+	// The Pokemon form is in the cache. Decode it from a gob
+	var pokemonForm pokeapi.PokemonForm
+	buf := bytes.NewBuffer(item.Value)
+	dec := gob.NewDecoder(buf)
+	err = dec.Decode(&pokemonForm)
+	if err != nil {
+		return pokeapi.PokemonForm{}, err
+	}
+	return pokemonForm, nil
+}
+
 func init() {
 	pokeapi.Register("gae", GAEFetcher{})
 }
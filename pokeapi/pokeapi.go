@@ -13,6 +13,8 @@ const (
 	pokemonEP        = "pokemon/"
 	moveEP           = "move/"
 	pokemonSpeciesEP = "pokemon-species/"
+	evolutionChainEP = "evolution-chain/"
+	pokemonFormEP    = "pokemon-form/"
 )
 
 func idFromURL(url string) (int, error) {
@@ -0,0 +1,150 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/velovix/snoreslacks/messaging"
+	"github.com/velovix/snoreslacks/pkmn"
+
+	"golang.org/x/net/context"
+)
+
+// PokemonForm contains the response data from PokeAPI regarding one of the
+// forms a Pokemon can take, like one of Unown's letters. Forms only differ in
+// looks.
+type PokemonForm struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	FormName     string `json:"form_name"`
+	IsDefault    bool   `json:"is_default"`
+	IsBattleOnly bool   `json:"is_battle_only"`
+	IsMega       bool   `json:"is_mega"`
+	Sprites      struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+	} `json:"sprites"`
+}
+
+// FetchPokemonForm queries PokeAPI directly for the Pokemon form with the
+// given ID. This function should be avoided in favor of using a Fetcher.
+func FetchPokemonForm(id int, client messaging.Client) (PokemonForm, error) {
+	// Query the API
+	resp, err := client.Get(apiURL + pokemonFormEP + strconv.Itoa(id) + "/")
+	if err != nil {
+		return PokemonForm{}, errors.Wrap(err, "fetching a Pokemon form")
+	}
+	defer resp.Body.Close()
+
+	// Read the response data
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return PokemonForm{}, errors.Wrap(err, "reading Pokemon form data")
+	}
+
+	// Unmarshal the response into a Pokemon form object
+	var f PokemonForm
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return PokemonForm{}, errors.Wrap(err, "parsing Pokemon form data")
+	}
+
+	return f, nil
+}
+
+// wildVarietySuffixes are the name suffixes of the species varieties that can
+// be found in the wild alongside the default one. Megas and other varieties
+// that only appear in battle are left out.
+var wildVarietySuffixes = []string{"alola", "galar", "hisui", "paldea"}
+
+// RandomWildVariety picks the variety a wild member of the species with the
+// given ID is found as, which is either the species' default variety or one
+// of its regional variants, and returns its PokeAPI Pokemon data.
+func RandomWildVariety(ctx context.Context, client messaging.Client, fetcher Fetcher, rng pkmn.RNG, speciesID int) (Pokemon, error) {
+	species, err := fetcher.FetchPokemonSpecies(ctx, client, speciesID)
+	if err != nil {
+		return Pokemon{}, err
+	}
+
+	// The default variety shares its ID with the species
+	candidates := []int{speciesID}
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			continue
+		}
+		for _, suffix := range wildVarietySuffixes {
+			if variety.Pokemon.Name == species.Name+"-"+suffix {
+				id, err := idFromURL(variety.Pokemon.URL)
+				if err != nil {
+					return Pokemon{}, err
+				}
+				candidates = append(candidates, id)
+			}
+		}
+	}
+
+	return fetcher.FetchPokemon(ctx, client, candidates[rng.Intn(len(candidates))])
+}
+
+// FetchVariety returns the PokeAPI Pokemon data of the variety of the species
+// with the given ID that goes by the given form, like a regional variant. The
+// species' default variety is returned if none of its varieties go by the
+// form.
+func FetchVariety(ctx context.Context, client messaging.Client, fetcher Fetcher, speciesID int, form string) (Pokemon, error) {
+	if form == "" {
+		return fetcher.FetchPokemon(ctx, client, speciesID)
+	}
+
+	species, err := fetcher.FetchPokemonSpecies(ctx, client, speciesID)
+	if err != nil {
+		return Pokemon{}, err
+	}
+
+	// The default variety shares its ID with the species
+	varietyID := speciesID
+	for _, variety := range species.Varieties {
+		if !variety.IsDefault && variety.Pokemon.Name == species.Name+"-"+form {
+			varietyID, err = idFromURL(variety.Pokemon.URL)
+			if err != nil {
+				return Pokemon{}, err
+			}
+			break
+		}
+	}
+
+	return fetcher.FetchPokemon(ctx, client, varietyID)
+}
+
+// RandomizeForm gives the Pokemon one of the forms the PokeAPI Pokemon it was
+// made from can take, picked at random. Pokemon that only have one form are
+// left as they are, as are Pokemon that land on a form that only appears in
+// battle.
+func RandomizeForm(ctx context.Context, client messaging.Client, fetcher Fetcher, rng pkmn.RNG, apiPkmn Pokemon, p *pkmn.Pokemon) error {
+	if len(apiPkmn.Forms) <= 1 {
+		return nil
+	}
+
+	formID, err := idFromURL(apiPkmn.Forms[rng.Intn(len(apiPkmn.Forms))].URL)
+	if err != nil {
+		return err
+	}
+	form, err := fetcher.FetchPokemonForm(ctx, client, formID)
+	if err != nil {
+		return err
+	}
+	if form.IsDefault || form.IsBattleOnly || form.IsMega || form.FormName == "" {
+		return nil
+	}
+
+	p.Form = form.FormName
+	if form.Sprites.FrontDefault != "" {
+		p.SpriteURL = form.Sprites.FrontDefault
+	}
+	if form.Sprites.FrontShiny != "" {
+		p.ShinySpriteURL = form.Sprites.FrontShiny
+	}
+
+	return nil
+}
@@ -3,19 +3,55 @@ package pokeapi
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/velovix/snoreslacks/messaging"
 )
 
 type PokemonSpecies struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	CaptureRate int    `json:"capture_rate"`
-	GrowthRate  struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	CaptureRate   int    `json:"capture_rate"`
+	BaseHappiness int    `json:"base_happiness"`
+	GrowthRate    struct {
 		Name string `json:"name"`
 	} `json:"growth_rate"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"flavor_text_entries"`
+}
+
+// ErrNoSuchSpecies is returned when PokeAPI has no Pokemon species by the
+// requested name.
+var ErrNoSuchSpecies = errors.New("no such Pokemon species")
+
+// FlavorText returns the species' Pokedex description from the most recent
+// game that has one in English, or an empty string if there is none. PokeAPI
+// keeps the line breaks from the games, so whitespace is collapsed.
+func (s PokemonSpecies) FlavorText() string {
+	for i := len(s.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := s.FlavorTextEntries[i]
+		if entry.Language.Name == "en" {
+			return strings.Join(strings.Fields(entry.FlavorText), " ")
+		}
+	}
+	return ""
 }
 
 // FetchPokemonSpecies queries PokeAPI directly for a Pokemon species with
@@ -69,3 +105,34 @@ func FetchPokemonSpeciesFromURL(url string, client messaging.Client) (PokemonSpe
 
 	return p, nil
 }
+
+// FetchPokemonSpeciesByName queries PokeAPI directly for the Pokemon species
+// with the given name. ErrNoSuchSpecies is returned if there is no such
+// species. Results are not cached, so this should only be used to find the
+// ID of a species.
+func FetchPokemonSpeciesByName(name string, client messaging.Client) (PokemonSpecies, error) {
+	// Query the API
+	resp, err := client.Get(apiURL + pokemonSpeciesEP + strings.ToLower(name) + "/")
+	if err != nil {
+		return PokemonSpecies{}, errors.Wrap(err, "fetching a Pokemon species")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return PokemonSpecies{}, ErrNoSuchSpecies
+	}
+
+	// Read the response data
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return PokemonSpecies{}, errors.Wrap(err, "reading Pokemon species data")
+	}
+
+	// Unmarshal the response into a Pokemon species object
+	var p PokemonSpecies
+	err = json.Unmarshal(data, &p)
+	if err != nil {
+		return PokemonSpecies{}, errors.Wrap(err, "parsing Pokemon species data")
+	}
+
+	return p, nil
+}
@@ -3,7 +3,9 @@ package pokeapi
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math/rand"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -30,11 +32,24 @@ type Pokemon struct {
 	} `json:"types"`
 	Sprites struct {
 		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
 	} `json:"sprites"`
+	Forms []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"forms"`
 	Species struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"species"`
+	Abilities []struct {
+		IsHidden bool `json:"is_hidden"`
+		Slot     int  `json:"slot"`
+		Ability  struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"ability"`
+	} `json:"abilities"`
 	Stats []struct {
 		BaseStat int `json:"base_stat"`
 		Effort   int `json:"effort"`
@@ -103,6 +118,18 @@ func MakePokemonFromBytes(data []byte) (Pokemon, error) {
 	return p, nil
 }
 
+// RegularAbilities returns the names of the Pokemon's abilities that aren't
+// hidden, keyed by their slot.
+func RegularAbilities(apiPkmn Pokemon) map[int]string {
+	abilities := make(map[int]string)
+	for _, val := range apiPkmn.Abilities {
+		if !val.IsHidden {
+			abilities[val.Slot] = val.Ability.Name
+		}
+	}
+	return abilities
+}
+
 // NewPokemon creates a new Pokemon from the given PokeAPI Pokemon data.
 func NewPokemon(ctx context.Context, client messaging.Client, fetcher Fetcher, apiPkmn Pokemon, level int) (pkmn.Pokemon, error) {
 	var p pkmn.Pokemon
@@ -124,22 +151,40 @@ func NewPokemon(ctx context.Context, client messaging.Client, fetcher Fetcher, a
 
 	// Fill in the sprite info
 	p.SpriteURL = apiPkmn.Sprites.FrontDefault
+	p.ShinySpriteURL = apiPkmn.Sprites.FrontShiny
 
-	// Fill in the stat values
+	// Every new Pokemon gets a random nature
+	p.Nature = pkmn.RandomNature()
+
+	// Pick a random ability out of the Pokemon's regular abilities. Hidden
+	// abilities are never given out.
+	var abilitySlots []int
+	for _, val := range apiPkmn.Abilities {
+		if !val.IsHidden {
+			abilitySlots = append(abilitySlots, val.Slot)
+		}
+	}
+	if len(abilitySlots) > 0 {
+		p.AbilitySlot = abilitySlots[rand.Intn(len(abilitySlots))]
+		p.Ability = RegularAbilities(apiPkmn)[p.AbilitySlot]
+	}
+
+	// Fill in the stat values, giving each stat a random IV and recording
+	// the effort values this Pokemon gives when defeated
 	for _, val := range apiPkmn.Stats {
 		switch val.Stat.Name {
 		case "hp":
-			p.HP = pkmn.Stat{Base: val.BaseStat}
+			p.HP = pkmn.Stat{Base: val.BaseStat, IV: pkmn.RandomIV(), EVYield: val.Effort}
 		case "attack":
-			p.Attack = pkmn.Stat{Base: val.BaseStat}
+			p.Attack = pkmn.Stat{Base: val.BaseStat, IV: pkmn.RandomIV(), EVYield: val.Effort}
 		case "defense":
-			p.Defense = pkmn.Stat{Base: val.BaseStat}
+			p.Defense = pkmn.Stat{Base: val.BaseStat, IV: pkmn.RandomIV(), EVYield: val.Effort}
 		case "special-attack":
-			p.SpAttack = pkmn.Stat{Base: val.BaseStat}
+			p.SpAttack = pkmn.Stat{Base: val.BaseStat, IV: pkmn.RandomIV(), EVYield: val.Effort}
 		case "special-defense":
-			p.SpDefense = pkmn.Stat{Base: val.BaseStat}
+			p.SpDefense = pkmn.Stat{Base: val.BaseStat, IV: pkmn.RandomIV(), EVYield: val.Effort}
 		case "speed":
-			p.Speed = pkmn.Stat{Base: val.BaseStat}
+			p.Speed = pkmn.Stat{Base: val.BaseStat, IV: pkmn.RandomIV(), EVYield: val.Effort}
 		default:
 			return pkmn.Pokemon{}, errors.New("unsupported stat '" + val.Stat.Name + "'")
 		}
@@ -156,7 +201,7 @@ func NewPokemon(ctx context.Context, client messaging.Client, fetcher Fetcher, a
 		}
 	}
 
-	// Get species information for the catch rate and growth rate
+	// Get species information for the catch rate, growth rate and friendship
 	speciesID, err := idFromURL(apiPkmn.Species.URL)
 	if err != nil {
 		return pkmn.Pokemon{}, err
@@ -167,6 +212,16 @@ func NewPokemon(ctx context.Context, client messaging.Client, fetcher Fetcher, a
 	}
 	p.CatchRate = species.CaptureRate
 
+	// Varieties other than the default, like regional variants, have their
+	// own PokeAPI IDs. The Pokemon is still a member of the species, so it
+	// keeps the species' ID and name and goes by the variety as its form.
+	if apiPkmn.ID != species.ID {
+		p.ID = species.ID
+		p.Name = species.Name
+		p.Form = strings.TrimPrefix(apiPkmn.Name, species.Name+"-")
+	}
+	p.Friendship = species.BaseHappiness
+
 	// Fill up the growth rate value
 	switch species.GrowthRate.Name {
 	case "erratic":
@@ -186,6 +241,7 @@ func NewPokemon(ctx context.Context, client messaging.Client, fetcher Fetcher, a
 	}
 	p.Level = level
 	p.Experience = pkmn.RequiredExperience(p.GrowthRate, p.Level)
+	p.ExperienceVersion = pkmn.CurrentExperienceVersion
 
 	// Learn any necessary moves
 	moveIDs := make([]int, 4)
@@ -217,12 +273,13 @@ func NewPokemon(ctx context.Context, client messaging.Client, fetcher Fetcher, a
 }
 
 // FetchLevelLearnableMoveIDs returns a map containing information on all moves
-// that can be learned by the Pokemon with the given ID by leveling up alone.
-// The returned map has levels as the key and a slice of moves that can be
-// learned at that level as its value.
-func FetchLevelLearnableMoveIDs(ctx context.Context, client messaging.Client, fetcher Fetcher, pkmnID int) (map[int][]int, error) {
+// that can be learned by leveling up alone by the Pokemon of the species with
+// the given ID that goes by the given form. Regional variants learn different
+// moves than the rest of their species. The returned map has levels as the key
+// and a slice of moves that can be learned at that level as its value.
+func FetchLevelLearnableMoveIDs(ctx context.Context, client messaging.Client, fetcher Fetcher, speciesID int, form string) (map[int][]int, error) {
 	// Fetch Pokemon info from PokeAPI to extract move information from
-	apiPkmn, err := fetcher.FetchPokemon(ctx, client, pkmnID)
+	apiPkmn, err := FetchVariety(ctx, client, fetcher, speciesID, form)
 	if err != nil {
 		return make(map[int][]int), err
 	}
@@ -250,3 +307,46 @@ func FetchLevelLearnableMoveIDs(ctx context.Context, client messaging.Client, fe
 
 	return moveIDs, nil
 }
+
+// NewDexEntry creates the Pokedex entry of a species from its PokeAPI Pokemon
+// and species data.
+func NewDexEntry(apiPkmn Pokemon, species PokemonSpecies) (pkmn.DexEntry, error) {
+	entry := pkmn.DexEntry{
+		ID:         species.ID,
+		Name:       species.Name,
+		SpriteURL:  apiPkmn.Sprites.FrontDefault,
+		FlavorText: species.FlavorText()}
+
+	// Assign types
+	for _, t := range apiPkmn.Types {
+		if t.Slot == 1 {
+			entry.Type1 = t.Type.Name
+		} else if t.Slot == 2 {
+			entry.Type2 = t.Type.Name
+		} else {
+			return pkmn.DexEntry{}, errors.New("unsupported type slot '" + strconv.Itoa(t.Slot) + "'")
+		}
+	}
+
+	// Fill in the base stats
+	for _, val := range apiPkmn.Stats {
+		switch val.Stat.Name {
+		case "hp":
+			entry.HP = val.BaseStat
+		case "attack":
+			entry.Attack = val.BaseStat
+		case "defense":
+			entry.Defense = val.BaseStat
+		case "special-attack":
+			entry.SpAttack = val.BaseStat
+		case "special-defense":
+			entry.SpDefense = val.BaseStat
+		case "speed":
+			entry.Speed = val.BaseStat
+		default:
+			return pkmn.DexEntry{}, errors.New("unsupported stat '" + val.Stat.Name + "'")
+		}
+	}
+
+	return entry, nil
+}